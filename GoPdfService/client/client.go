@@ -0,0 +1,256 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// RequestEditorFn lets a caller mutate an outgoing request before it is
+// sent, e.g. to attach an Authorization header.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// HttpRequestDoer is satisfied by *http.Client and any RoundTripper-backed
+// client a caller wants to substitute (retry wrappers, test doubles, …).
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client is the low-level SDK client: every method returns the raw
+// *http.Response with its body left open for the caller, or for
+// ClientWithResponses, to read and close.
+type Client struct {
+	Server         string
+	Client         HttpRequestDoer
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption configures a Client built by NewClient.
+type ClientOption func(*Client) error
+
+// NewClient builds a Client against server, applying any ClientOptions.
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	c := &Client{Server: strings.TrimRight(server, "/"), Client: &http.Client{}}
+	for _, opt := range opts {
+		if err := opt(c); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// WithHTTPClient overrides the HttpRequestDoer used to send requests — pass
+// a client built with NewRetryTransport to get retry behaviour.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn registers a RequestEditorFn run on every outgoing
+// request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// WithAuthToken attaches an `Authorization: Bearer <token>` header to every
+// request the client sends.
+func WithAuthToken(token string) ClientOption {
+	return WithRequestEditorFn(func(_ context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer "+token)
+		return nil
+	})
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request) error {
+	for _, fn := range c.RequestEditors {
+		if err := fn(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	if err := c.applyEditors(ctx, req); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// Health calls GET /health.
+func (c *Client) Health(ctx context.Context) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Server+"/health", nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.send(ctx, req)
+}
+
+// GetSupportedFormats calls GET /api/pdf/supported-formats.
+func (c *Client) GetSupportedFormats(ctx context.Context) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Server+"/api/pdf/supported-formats", nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.send(ctx, req)
+}
+
+// MergePdfs calls POST /api/pdf/merge-pdfs, uploading each (filename, reader)
+// pair in files under the "files" form field.
+func (c *Client) MergePdfs(ctx context.Context, files []NamedReader, params MergePdfsParams) (*http.Response, error) {
+	body, contentType, err := encodeMultipart(func(w *multipart.Writer) error {
+		for _, f := range files {
+			if err := writeFilePart(w, "files", f.Name, f.Reader); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	if params.OutputFormat != "" {
+		q.Set("outputFormat", params.OutputFormat)
+	}
+	if params.XrefStream {
+		q.Set("xrefStream", "1")
+	}
+	if params.Store {
+		q.Set("store", "1")
+	}
+
+	return c.postMultipart(ctx, "/api/pdf/merge-pdfs", q, body, contentType)
+}
+
+// SignPdf calls POST /api/pdf/sign.
+func (c *Client) SignPdf(ctx context.Context, b SignPdfMultipartBody, params SignPdfParams) (*http.Response, error) {
+	body, contentType, err := encodeMultipart(func(w *multipart.Writer) error {
+		if err := writeFilePart(w, "file", b.Filename, bytes.NewReader(b.File)); err != nil {
+			return err
+		}
+		if len(b.P12) > 0 {
+			if err := writeFilePart(w, "p12", b.P12Filename, bytes.NewReader(b.P12)); err != nil {
+				return err
+			}
+		}
+		fields := map[string]string{
+			"password": b.Password,
+			"reason":   b.Reason,
+			"location": b.Location,
+		}
+		if b.Page > 0 {
+			fields["page"] = strconv.Itoa(b.Page)
+		}
+		if b.CAdES {
+			fields["cades"] = "true"
+		}
+		for k, v := range fields {
+			if v == "" {
+				continue
+			}
+			if err := w.WriteField(k, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	if params.Store {
+		q.Set("store", "1")
+	}
+
+	return c.postMultipart(ctx, "/api/pdf/sign", q, body, contentType)
+}
+
+// VerifySignature calls POST /api/pdf/verify-signature.
+func (c *Client) VerifySignature(ctx context.Context, filename string, r io.Reader) (*http.Response, error) {
+	body, contentType, err := encodeMultipart(func(w *multipart.Writer) error {
+		return writeFilePart(w, "file", filename, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return c.postMultipart(ctx, "/api/pdf/verify-signature", nil, body, contentType)
+}
+
+// ReadAllCodes calls POST /api/pdf/read-all-codes.
+func (c *Client) ReadAllCodes(ctx context.Context, filename string, r io.Reader, params ReadCodesParams) (*http.Response, error) {
+	body, contentType, err := encodeMultipart(func(w *multipart.Writer) error {
+		return writeFilePart(w, "file", filename, r)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	if params.Symbologies != "" {
+		q.Set("symbologies", params.Symbologies)
+	}
+	if params.MaxPages > 0 {
+		q.Set("maxPages", strconv.Itoa(params.MaxPages))
+	}
+	if params.DPI > 0 {
+		q.Set("dpi", strconv.Itoa(params.DPI))
+	}
+
+	return c.postMultipart(ctx, "/api/pdf/read-all-codes", q, body, contentType)
+}
+
+func (c *Client) postMultipart(ctx context.Context, path string, query url.Values, body []byte, contentType string) (*http.Response, error) {
+	target := c.Server + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return c.send(ctx, req)
+}
+
+// NamedReader pairs an upload's on-disk filename with its content, for
+// endpoints that accept multiple files under the same form field.
+type NamedReader struct {
+	Name   string
+	Reader io.Reader
+}
+
+func encodeMultipart(write func(w *multipart.Writer) error) ([]byte, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := write(w); err != nil {
+		return nil, "", fmt.Errorf("client: encoding multipart body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, "", fmt.Errorf("client: finalizing multipart body: %w", err)
+	}
+	return buf.Bytes(), w.FormDataContentType(), nil
+}
+
+func writeFilePart(w *multipart.Writer, field, filename string, r io.Reader) error {
+	part, err := w.CreateFormFile(field, filename)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(part, r)
+	return err
+}
@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// TestMergePdfsSendsXrefStreamAsOne pins the client to the server's
+// "1" boolean-query convention (see mergeOptionsFromQuery), not the bare
+// "true" a naive bool->string conversion would send.
+func TestMergePdfsSendsXrefStreamAsOne(t *testing.T) {
+	var got url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.Query()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c, err := NewClient(srv.URL)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+
+	files := []NamedReader{{Name: "a.pdf", Reader: strings.NewReader("")}}
+	_, err = c.MergePdfs(context.Background(), files, MergePdfsParams{XrefStream: true})
+	if err != nil {
+		t.Fatalf("MergePdfs: %v", err)
+	}
+
+	if v := got.Get("xrefStream"); v != "1" {
+		t.Fatalf("xrefStream query param = %q, want %q", v, "1")
+	}
+}
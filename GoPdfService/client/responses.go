@@ -0,0 +1,198 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ClientWithResponses wraps Client and parses each call's response body
+// into typed JSON200/JSONDefault fields, so callers don't deal with
+// *http.Response directly.
+type ClientWithResponses struct {
+	*Client
+}
+
+// NewClientWithResponses builds a ClientWithResponses against server.
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	c, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{Client: c}, nil
+}
+
+// HealthResp is the parsed result of a Health call.
+type HealthResp struct {
+	HTTPResponse *http.Response
+	Body         []byte
+	JSON200      *HealthResponse
+	JSONDefault  *ErrorResponse
+}
+
+// StatusCode reports the HTTP status code of the underlying response.
+func (r HealthResp) StatusCode() int { return r.HTTPResponse.StatusCode }
+
+// HealthWithResponse calls Health and parses the result.
+func (c *ClientWithResponses) HealthWithResponse(ctx context.Context) (*HealthResp, error) {
+	resp, err := c.Health(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	parsed := &HealthResp{HTTPResponse: resp}
+	if parsed.Body, err = io.ReadAll(resp.Body); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		parsed.JSON200 = &HealthResponse{}
+		return parsed, json.Unmarshal(parsed.Body, parsed.JSON200)
+	}
+	return parsed, parseErrorBody(parsed.Body, &parsed.JSONDefault)
+}
+
+// SupportedFormatsResp is the parsed result of a GetSupportedFormats call.
+type SupportedFormatsResp struct {
+	HTTPResponse *http.Response
+	Body         []byte
+	JSON200      *SupportedFormatsResponse
+}
+
+func (r SupportedFormatsResp) StatusCode() int { return r.HTTPResponse.StatusCode }
+
+// GetSupportedFormatsWithResponse calls GetSupportedFormats and parses the result.
+func (c *ClientWithResponses) GetSupportedFormatsWithResponse(ctx context.Context) (*SupportedFormatsResp, error) {
+	resp, err := c.GetSupportedFormats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	parsed := &SupportedFormatsResp{HTTPResponse: resp}
+	if parsed.Body, err = io.ReadAll(resp.Body); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		parsed.JSON200 = &SupportedFormatsResponse{}
+		return parsed, json.Unmarshal(parsed.Body, parsed.JSON200)
+	}
+	return parsed, nil
+}
+
+// FileResp is the parsed result of an endpoint that normally streams a file
+// back, unless called with Store: true, in which case JSON200Artifact is
+// populated instead of Body holding the file's bytes.
+type FileResp struct {
+	HTTPResponse    *http.Response
+	Body            []byte
+	JSON200Artifact *ArtifactResponse
+	JSONDefault     *ErrorResponse
+}
+
+func (r FileResp) StatusCode() int { return r.HTTPResponse.StatusCode }
+
+func parseFileResponse(resp *http.Response) (*FileResp, error) {
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	parsed := &FileResp{HTTPResponse: resp, Body: data}
+
+	if resp.StatusCode != http.StatusOK {
+		return parsed, parseErrorBody(data, &parsed.JSONDefault)
+	}
+	if ct := resp.Header.Get("Content-Type"); len(ct) >= len("application/json") && ct[:len("application/json")] == "application/json" {
+		parsed.JSON200Artifact = &ArtifactResponse{}
+		return parsed, json.Unmarshal(data, parsed.JSON200Artifact)
+	}
+	return parsed, nil
+}
+
+// MergePdfsWithResponse calls MergePdfs and parses the result.
+func (c *ClientWithResponses) MergePdfsWithResponse(ctx context.Context, files []NamedReader, params MergePdfsParams) (*FileResp, error) {
+	resp, err := c.MergePdfs(ctx, files, params)
+	if err != nil {
+		return nil, err
+	}
+	return parseFileResponse(resp)
+}
+
+// SignPdfWithResponse calls SignPdf and parses the result.
+func (c *ClientWithResponses) SignPdfWithResponse(ctx context.Context, body SignPdfMultipartBody, params SignPdfParams) (*FileResp, error) {
+	resp, err := c.SignPdf(ctx, body, params)
+	if err != nil {
+		return nil, err
+	}
+	return parseFileResponse(resp)
+}
+
+// VerifySignatureResp is the parsed result of a VerifySignature call.
+type VerifySignatureResp struct {
+	HTTPResponse *http.Response
+	Body         []byte
+	JSON200      *VerifyResult
+}
+
+func (r VerifySignatureResp) StatusCode() int { return r.HTTPResponse.StatusCode }
+
+// VerifySignatureWithResponse calls VerifySignature and parses the result.
+func (c *ClientWithResponses) VerifySignatureWithResponse(ctx context.Context, filename string, r io.Reader) (*VerifySignatureResp, error) {
+	resp, err := c.VerifySignature(ctx, filename, r)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	parsed := &VerifySignatureResp{HTTPResponse: resp}
+	if parsed.Body, err = io.ReadAll(resp.Body); err != nil {
+		return nil, err
+	}
+	parsed.JSON200 = &VerifyResult{}
+	return parsed, json.Unmarshal(parsed.Body, parsed.JSON200)
+}
+
+// ReadAllCodesResp is the parsed result of a ReadAllCodes call.
+type ReadAllCodesResp struct {
+	HTTPResponse *http.Response
+	Body         []byte
+	JSON200      *DecodeResult
+	JSONDefault  *ErrorResponse
+}
+
+func (r ReadAllCodesResp) StatusCode() int { return r.HTTPResponse.StatusCode }
+
+// ReadAllCodesWithResponse calls ReadAllCodes and parses the result.
+func (c *ClientWithResponses) ReadAllCodesWithResponse(ctx context.Context, filename string, r io.Reader, params ReadCodesParams) (*ReadAllCodesResp, error) {
+	resp, err := c.ReadAllCodes(ctx, filename, r, params)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	parsed := &ReadAllCodesResp{HTTPResponse: resp}
+	if parsed.Body, err = io.ReadAll(resp.Body); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusOK {
+		parsed.JSON200 = &DecodeResult{}
+		return parsed, json.Unmarshal(parsed.Body, parsed.JSON200)
+	}
+	return parsed, parseErrorBody(parsed.Body, &parsed.JSONDefault)
+}
+
+func parseErrorBody(data []byte, dst **ErrorResponse) error {
+	if len(data) == 0 {
+		return nil
+	}
+	e := &ErrorResponse{}
+	if err := json.Unmarshal(data, e); err != nil {
+		return fmt.Errorf("client: decoding error response: %w", err)
+	}
+	*dst = e
+	return nil
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"time"
+)
+
+// RetryTransport wraps an http.RoundTripper and retries requests that fail
+// with a network error or a 5xx response, using exponential backoff.
+type RetryTransport struct {
+	Base       http.RoundTripper
+	MaxRetries int
+	BaseDelay  time.Duration
+}
+
+// NewRetryTransport builds a RetryTransport with sane defaults: 3 retries,
+// 200ms initial backoff doubling each attempt.
+func NewRetryTransport(base http.RoundTripper) *RetryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &RetryTransport{Base: base, MaxRetries: 3, BaseDelay: 200 * time.Millisecond}
+}
+
+// NewRetryClient builds an *http.Client whose transport is a RetryTransport,
+// ready to pass to client.WithHTTPClient.
+func NewRetryClient() *http.Client {
+	return &http.Client{Transport: NewRetryTransport(nil)}
+}
+
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+		body = b
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 0; attempt <= t.MaxRetries; attempt++ {
+		if body != nil {
+			req.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err = t.Base.RoundTrip(req)
+		if err == nil && resp.StatusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+		if attempt == t.MaxRetries {
+			break
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		time.Sleep(t.BaseDelay << attempt)
+	}
+	return resp, err
+}
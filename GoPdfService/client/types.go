@@ -0,0 +1,113 @@
+// Package client is a typed Go SDK for the Go PDF Service, generated via
+// oapi-codegen from docs/openapi3.yaml. Regenerate it after changing the
+// spec instead of hand-editing these files.
+package client
+
+import "time"
+
+// HealthResponse mirrors main.HealthResponse.
+type HealthResponse struct {
+	Status    string    `json:"status"`
+	Service   string    `json:"service"`
+	Timestamp time.Time `json:"timestamp"`
+	Version   string    `json:"version"`
+}
+
+// SupportedFormatsResponse mirrors main.SupportedFormatsResponse.
+type SupportedFormatsResponse struct {
+	Service                  string               `json:"service"`
+	Description              string               `json:"description"`
+	SupportedImageFormats    []string             `json:"supportedImageFormats"`
+	SupportedPdfFormats      []string             `json:"supportedPdfFormats"`
+	SupportedDocumentFormats []string             `json:"supportedDocumentFormats"`
+	SupportedOutputFormats   []string             `json:"supportedOutputFormats"`
+	Features                 []string             `json:"features"`
+	Limits                   UploadLimitsResponse `json:"limits"`
+}
+
+// UploadLimitsResponse mirrors main.UploadLimitsResponse.
+type UploadLimitsResponse struct {
+	MaxFileBytes             int64 `json:"maxFileBytes"`
+	MaxRequestBytes          int64 `json:"maxRequestBytes"`
+	MaxPages                 int   `json:"maxPages"`
+	MaxConcurrentConversions int   `json:"maxConcurrentConversions"`
+}
+
+// ErrorResponse mirrors main.ErrorResponse.
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// ArtifactResponse is returned by file-producing endpoints when called with
+// ?store=1 instead of the raw file bytes.
+type ArtifactResponse struct {
+	Key         string `json:"key"`
+	DownloadUrl string `json:"downloadUrl"`
+	ContentType string `json:"contentType"`
+	Filename    string `json:"filename"`
+}
+
+// VerifyResult mirrors the JSON body returned by POST /api/pdf/verify-signature.
+type VerifyResult struct {
+	Verified     bool      `json:"verified"`
+	ChainTrusted bool      `json:"chainTrusted"`
+	SignerDN     string    `json:"signerDN"`
+	SigningTime  time.Time `json:"signingTime"`
+	Reason       string    `json:"reason"`
+	Location     string    `json:"location"`
+	SubFilter    string    `json:"subFilter"`
+	Errors       []string  `json:"errors"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// CodeResult is one decoded barcode/QR code.
+type CodeResult struct {
+	Text       string  `json:"text"`
+	Symbology  string  `json:"symbology"`
+	Page       int     `json:"page"`
+	Confidence float64 `json:"confidence"`
+}
+
+// DecodeResult mirrors the JSON body returned by POST
+// /api/pdf/read-all-codes; read-barcodes and read-qr-codes return their
+// results under "barcodes" and "qrCodes" instead of "all".
+type DecodeResult struct {
+	Success   bool         `json:"success"`
+	All       []CodeResult `json:"all"`
+	Count     int          `json:"count"`
+	Timestamp time.Time    `json:"timestamp"`
+}
+
+// MergePdfsParams are the query parameters accepted by POST /api/pdf/merge-pdfs.
+type MergePdfsParams struct {
+	OutputFormat string
+	XrefStream   bool
+	Store        bool
+}
+
+// SignPdfParams are the query parameters accepted by POST /api/pdf/sign.
+type SignPdfParams struct {
+	Store bool
+}
+
+// SignPdfMultipartBody is the multipart/form-data body for POST /api/pdf/sign.
+type SignPdfMultipartBody struct {
+	File     []byte
+	Filename string
+
+	P12         []byte
+	P12Filename string
+
+	Password string
+	Reason   string
+	Location string
+	Page     int
+	CAdES    bool
+}
+
+// ReadCodesParams are the query parameters shared by the read-*-codes endpoints.
+type ReadCodesParams struct {
+	Symbologies string
+	MaxPages    int
+	DPI         int
+}
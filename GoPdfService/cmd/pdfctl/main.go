@@ -0,0 +1,208 @@
+// Command pdfctl is a small CLI over the generated client SDK, so contract
+// drift between the service and its consumers is caught at build time
+// rather than at runtime.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/leszekszpunar/IronPDF-examples/GoPdfService/client"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	server := flag.NewFlagSet("pdfctl", flag.ExitOnError)
+	serverURL := server.String("server", "http://localhost:5034", "adres serwisu Go PDF Service")
+	authToken := server.String("token", "", "opcjonalny token Bearer do autoryzacji")
+
+	cmd := os.Args[1]
+	if err := server.Parse(os.Args[2:]); err != nil {
+		os.Exit(2)
+	}
+
+	opts := []client.ClientOption{client.WithHTTPClient(client.NewRetryClient())}
+	if *authToken != "" {
+		opts = append(opts, client.WithAuthToken(*authToken))
+	}
+
+	c, err := client.NewClientWithResponses(*serverURL, opts...)
+	if err != nil {
+		fatalf("Błąd podczas tworzenia klienta: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	switch cmd {
+	case "health":
+		runHealth(ctx, c)
+	case "formats":
+		runSupportedFormats(ctx, c)
+	case "merge":
+		runMerge(ctx, c, server.Args())
+	case "sign":
+		runSign(ctx, c, server.Args())
+	case "verify":
+		runVerify(ctx, c, server.Args())
+	case "read-codes":
+		runReadCodes(ctx, c, server.Args())
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `pdfctl <polecenie> [flagi]
+
+Polecenia:
+  health                 Sprawdza stan serwisu
+  formats                Wyświetla obsługiwane formaty
+  merge <plik.pdf>...    Łączy pliki PDF
+  sign <plik.pdf>        Podpisuje plik PDF (wymaga skonfigurowanego SIGNING_KEYSTORE_PATH)
+  verify <plik.pdf>      Weryfikuje podpis cyfrowy w pliku PDF
+  read-codes <plik>      Odczytuje kody kreskowe/QR z pliku
+
+Flagi wspólne:
+  -server string   Adres serwisu (domyślnie http://localhost:5034)
+  -token string    Token Bearer do autoryzacji`)
+}
+
+func fatalf(format string, args ...interface{}) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
+
+func printJSON(v interface{}) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		fatalf("Błąd podczas serializacji odpowiedzi: %v", err)
+	}
+}
+
+func runHealth(ctx context.Context, c *client.ClientWithResponses) {
+	resp, err := c.HealthWithResponse(ctx)
+	if err != nil {
+		fatalf("Błąd podczas wywołania /health: %v", err)
+	}
+	if resp.JSON200 == nil {
+		fatalf("Nieoczekiwana odpowiedź (status %d): %s", resp.StatusCode(), resp.Body)
+	}
+	printJSON(resp.JSON200)
+}
+
+func runSupportedFormats(ctx context.Context, c *client.ClientWithResponses) {
+	resp, err := c.GetSupportedFormatsWithResponse(ctx)
+	if err != nil {
+		fatalf("Błąd podczas wywołania /api/pdf/supported-formats: %v", err)
+	}
+	if resp.JSON200 == nil {
+		fatalf("Nieoczekiwana odpowiedź (status %d): %s", resp.StatusCode(), resp.Body)
+	}
+	printJSON(resp.JSON200)
+}
+
+func runMerge(ctx context.Context, c *client.ClientWithResponses, paths []string) {
+	if len(paths) == 0 {
+		fatalf("Użycie: pdfctl merge <plik.pdf>...")
+	}
+
+	var files []client.NamedReader
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			fatalf("Błąd podczas otwierania %s: %v", p, err)
+		}
+		defer f.Close()
+		files = append(files, client.NamedReader{Name: p, Reader: f})
+	}
+
+	resp, err := c.MergePdfsWithResponse(ctx, files, client.MergePdfsParams{})
+	if err != nil {
+		fatalf("Błąd podczas łączenia PDF: %v", err)
+	}
+	writeFileOrArtifact(resp, "merged.pdf")
+}
+
+func runSign(ctx context.Context, c *client.ClientWithResponses, paths []string) {
+	if len(paths) != 1 {
+		fatalf("Użycie: pdfctl sign <plik.pdf>")
+	}
+
+	data, err := os.ReadFile(paths[0])
+	if err != nil {
+		fatalf("Błąd podczas odczytu %s: %v", paths[0], err)
+	}
+
+	resp, err := c.SignPdfWithResponse(ctx, client.SignPdfMultipartBody{
+		File:     data,
+		Filename: paths[0],
+	}, client.SignPdfParams{})
+	if err != nil {
+		fatalf("Błąd podczas podpisywania PDF: %v", err)
+	}
+	writeFileOrArtifact(resp, "signed.pdf")
+}
+
+func runVerify(ctx context.Context, c *client.ClientWithResponses, paths []string) {
+	if len(paths) != 1 {
+		fatalf("Użycie: pdfctl verify <plik.pdf>")
+	}
+
+	f, err := os.Open(paths[0])
+	if err != nil {
+		fatalf("Błąd podczas otwierania %s: %v", paths[0], err)
+	}
+	defer f.Close()
+
+	resp, err := c.VerifySignatureWithResponse(ctx, paths[0], f)
+	if err != nil {
+		fatalf("Błąd podczas weryfikacji podpisu: %v", err)
+	}
+	printJSON(resp.JSON200)
+}
+
+func runReadCodes(ctx context.Context, c *client.ClientWithResponses, paths []string) {
+	if len(paths) != 1 {
+		fatalf("Użycie: pdfctl read-codes <plik>")
+	}
+
+	f, err := os.Open(paths[0])
+	if err != nil {
+		fatalf("Błąd podczas otwierania %s: %v", paths[0], err)
+	}
+	defer f.Close()
+
+	resp, err := c.ReadAllCodesWithResponse(ctx, paths[0], f, client.ReadCodesParams{})
+	if err != nil {
+		fatalf("Błąd podczas odczytu kodów: %v", err)
+	}
+	if resp.JSON200 == nil {
+		fatalf("Nieoczekiwana odpowiedź (status %d): %s", resp.StatusCode(), resp.Body)
+	}
+	printJSON(resp.JSON200)
+}
+
+func writeFileOrArtifact(resp *client.FileResp, defaultName string) {
+	if resp.JSON200Artifact != nil {
+		printJSON(resp.JSON200Artifact)
+		return
+	}
+	if resp.StatusCode() != 200 {
+		fatalf("Serwis zwrócił status %d: %s", resp.StatusCode(), resp.Body)
+	}
+	if err := os.WriteFile(defaultName, resp.Body, 0o644); err != nil {
+		fatalf("Błąd podczas zapisu %s: %v", defaultName, err)
+	}
+	fmt.Printf("Zapisano %s (%d bajtów)\n", defaultName, len(resp.Body))
+}
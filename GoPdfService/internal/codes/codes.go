@@ -0,0 +1,82 @@
+// Package codes decodes barcodes and QR/2D codes out of uploaded images and
+// PDFs: PDF pages are rasterized first, then every page (or the image
+// itself) is run through a symbology-aware decoder.
+package codes
+
+// Symbology identifies a barcode/2D-code format a caller may ask to decode.
+type Symbology string
+
+const (
+	SymbologyQR         Symbology = "QR_CODE"
+	SymbologyAztec      Symbology = "AZTEC"
+	SymbologyDataMatrix Symbology = "DATA_MATRIX"
+	SymbologyCode128    Symbology = "CODE_128"
+	SymbologyEAN13      Symbology = "EAN_13"
+	SymbologyEAN8       Symbology = "EAN_8"
+	SymbologyUPCA       Symbology = "UPC_A"
+	SymbologyCode39     Symbology = "CODE_39"
+)
+
+var all2D = []Symbology{SymbologyQR, SymbologyAztec, SymbologyDataMatrix}
+var all1D = []Symbology{SymbologyCode128, SymbologyEAN13, SymbologyEAN8, SymbologyUPCA, SymbologyCode39}
+
+// AllSymbologies lists every symbology this package can decode.
+func AllSymbologies() []Symbology {
+	out := make([]Symbology, 0, len(all2D)+len(all1D))
+	out = append(out, all2D...)
+	out = append(out, all1D...)
+	return out
+}
+
+// Point is a pixel or PDF user-space coordinate.
+type Point struct {
+	X float64
+	Y float64
+}
+
+// Result is one decoded code.
+type Result struct {
+	Text        string    `json:"text"`
+	Symbology   Symbology `json:"symbology"`
+	Page        int       `json:"page"` // 1-based; 0 for a plain image upload
+	BoundingBox []Point   `json:"boundingBox"`
+	Confidence  float64   `json:"confidence"`
+}
+
+// ParseSymbologies splits a comma-separated ?symbologies= query value into
+// the set of requested Symbology values, defaulting to every symbology this
+// package knows about when the value is empty.
+func ParseSymbologies(raw string) []Symbology {
+	if raw == "" {
+		return AllSymbologies()
+	}
+	var out []Symbology
+	start := 0
+	for i := 0; i <= len(raw); i++ {
+		if i == len(raw) || raw[i] == ',' {
+			if i > start {
+				out = append(out, Symbology(raw[start:i]))
+			}
+			start = i + 1
+		}
+	}
+	return out
+}
+
+func is2D(s Symbology) bool {
+	for _, c := range all2D {
+		if c == s {
+			return true
+		}
+	}
+	return false
+}
+
+func is1D(s Symbology) bool {
+	for _, c := range all1D {
+		if c == s {
+			return true
+		}
+	}
+	return false
+}
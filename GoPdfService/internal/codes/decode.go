@@ -0,0 +1,129 @@
+package codes
+
+import (
+	"image"
+
+	"github.com/makiuchi-d/gozxing"
+	"github.com/makiuchi-d/gozxing/aztec"
+	"github.com/makiuchi-d/gozxing/datamatrix"
+	"github.com/makiuchi-d/gozxing/oned"
+	"github.com/makiuchi-d/gozxing/qrcode"
+)
+
+// reader is satisfied by every per-symbology gozxing reader we use.
+type reader interface {
+	DecodeWithoutHints(img *gozxing.BinaryBitmap) (*gozxing.Result, error)
+}
+
+// readerEntry pairs a gozxing reader with the symbology it decodes.
+type readerEntry struct {
+	symbology Symbology
+	reader    reader
+}
+
+// readerFor builds the gozxing reader for a single symbology; gozxing has no
+// combined 1D reader, so each 1D format gets its own dedicated reader rather
+// than a shared multi-format one.
+func readerFor(s Symbology) reader {
+	switch s {
+	case SymbologyQR:
+		return qrcode.NewQRCodeReader()
+	case SymbologyAztec:
+		return aztec.NewAztecReader()
+	case SymbologyDataMatrix:
+		return datamatrix.NewDataMatrixReader()
+	case SymbologyCode128:
+		return oned.NewCode128Reader()
+	case SymbologyEAN13:
+		return oned.NewEAN13Reader()
+	case SymbologyEAN8:
+		return oned.NewEAN8Reader()
+	case SymbologyUPCA:
+		return oned.NewUPCAReader()
+	case SymbologyCode39:
+		return oned.NewCode39Reader()
+	default:
+		return nil
+	}
+}
+
+// readersFor builds one reader per requested symbology, skipping any that
+// aren't recognized.
+func readersFor(symbologies []Symbology) []readerEntry {
+	var entries []readerEntry
+	for _, s := range symbologies {
+		if r := readerFor(s); r != nil {
+			entries = append(entries, readerEntry{symbology: s, reader: r})
+		}
+	}
+	return entries
+}
+
+// DecodeImage runs every requested symbology's decoder over img (a plain
+// image upload, or one rasterized PDF page) and returns whatever is found.
+// page is 0 for a standalone image upload, or the 1-based PDF page number.
+func DecodeImage(img image.Image, symbologies []Symbology, page int, pointsPerPixel float64) ([]Result, error) {
+	bitmap, err := newBinaryBitmap(img)
+	if err != nil {
+		return nil, err
+	}
+
+	pageHeightPts := float64(bitmap.GetHeight()) * pointsPerPixel
+
+	var results []Result
+	for _, entry := range readersFor(symbologies) {
+		res, err := entry.reader.DecodeWithoutHints(bitmap)
+		if err != nil || res == nil {
+			continue
+		}
+
+		results = append(results, Result{
+			Text:        res.GetText(),
+			Symbology:   entry.symbology,
+			Page:        page,
+			BoundingBox: boundingBoxInPDFSpace(res, pointsPerPixel, pageHeightPts),
+			Confidence:  confidenceFor(res),
+		})
+	}
+	return results, nil
+}
+
+func newBinaryBitmap(img image.Image) (*gozxing.BinaryBitmap, error) {
+	source := gozxing.NewLuminanceSourceFromImage(img)
+	binarizer := gozxing.NewHybridBinarizer(source)
+	return gozxing.NewBinaryBitmap(binarizer)
+}
+
+// boundingBoxInPDFSpace converts a result's finder/alignment points from
+// pixel space (origin top-left, Y increasing downward) to PDF user space
+// (origin bottom-left, Y increasing upward), flipping Y against the page
+// height so the returned box lands where PDF content at these coordinates
+// actually is.
+func boundingBoxInPDFSpace(res *gozxing.Result, pointsPerPixel, pageHeightPts float64) []Point {
+	points := res.GetResultPoints()
+	out := make([]Point, 0, len(points))
+	for _, p := range points {
+		out = append(out, Point{
+			X: float64(p.GetX()) * pointsPerPixel,
+			Y: pageHeightPts - float64(p.GetY())*pointsPerPixel,
+		})
+	}
+	return out
+}
+
+// confidenceFor approximates a confidence score from how many finder/
+// alignment points the decoder reported; gozxing doesn't expose a native
+// confidence metric the way some commercial SDKs do.
+func confidenceFor(res *gozxing.Result) float64 {
+	points := res.GetResultPoints()
+	switch {
+	case len(points) >= 3:
+		return 0.95
+	case len(points) == 2:
+		return 0.85
+	case len(points) == 1:
+		return 0.7
+	default:
+		return 0.5
+	}
+}
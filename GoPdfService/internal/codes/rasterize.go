@@ -0,0 +1,113 @@
+package codes
+
+import (
+	"fmt"
+	"image"
+	_ "image/png"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/gen2brain/go-fitz"
+)
+
+// RasterizeOptions controls how PDF pages are turned into images before
+// decoding.
+type RasterizeOptions struct {
+	DPI      float64
+	MaxPages int
+	// PdftoppmPath, if set, forces the poppler `pdftoppm` shellout backend
+	// instead of go-fitz (handy on systems where linking MuPDF isn't an
+	// option but poppler-utils is already installed).
+	PdftoppmPath string
+}
+
+// RasterizePage is one rendered PDF page plus the scale factor needed to
+// convert a pixel coordinate on it back into PDF user-space points.
+type RasterizePage struct {
+	Image          image.Image
+	PointsPerPixel float64
+}
+
+// RasterizePDF renders up to opts.MaxPages pages of pdfBytes at opts.DPI.
+func RasterizePDF(pdfBytes []byte, opts RasterizeOptions) ([]RasterizePage, error) {
+	if opts.DPI <= 0 {
+		opts.DPI = 150
+	}
+	pointsPerPixel := 72.0 / opts.DPI
+
+	if opts.PdftoppmPath != "" {
+		return rasterizeWithPdftoppm(pdfBytes, opts, pointsPerPixel)
+	}
+	return rasterizeWithFitz(pdfBytes, opts, pointsPerPixel)
+}
+
+func rasterizeWithFitz(pdfBytes []byte, opts RasterizeOptions, pointsPerPixel float64) ([]RasterizePage, error) {
+	doc, err := fitz.NewFromMemory(pdfBytes)
+	if err != nil {
+		return nil, fmt.Errorf("codes: opening PDF with go-fitz: %w", err)
+	}
+	defer doc.Close()
+
+	pageCount := doc.NumPage()
+	if opts.MaxPages > 0 && pageCount > opts.MaxPages {
+		pageCount = opts.MaxPages
+	}
+
+	pages := make([]RasterizePage, 0, pageCount)
+	for i := 0; i < pageCount; i++ {
+		img, err := doc.ImageDPI(i, opts.DPI)
+		if err != nil {
+			return nil, fmt.Errorf("codes: rendering page %d: %w", i+1, err)
+		}
+		pages = append(pages, RasterizePage{Image: img, PointsPerPixel: pointsPerPixel})
+	}
+	return pages, nil
+}
+
+func rasterizeWithPdftoppm(pdfBytes []byte, opts RasterizeOptions, pointsPerPixel float64) ([]RasterizePage, error) {
+	tmpDir, err := os.MkdirTemp("", "pdftoppm-")
+	if err != nil {
+		return nil, fmt.Errorf("codes: creating temp dir: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	inputPath := filepath.Join(tmpDir, "input.pdf")
+	if err := os.WriteFile(inputPath, pdfBytes, 0o600); err != nil {
+		return nil, fmt.Errorf("codes: writing temp PDF: %w", err)
+	}
+
+	outPrefix := filepath.Join(tmpDir, "page")
+	args := []string{"-png", "-r", fmt.Sprintf("%g", opts.DPI)}
+	if opts.MaxPages > 0 {
+		args = append(args, "-l", fmt.Sprintf("%d", opts.MaxPages))
+	}
+	args = append(args, inputPath, outPrefix)
+
+	cmd := exec.Command(opts.PdftoppmPath, args...)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("codes: pdftoppm failed: %w (%s)", err, out)
+	}
+
+	matches, err := filepath.Glob(outPrefix + "*.png")
+	if err != nil {
+		return nil, fmt.Errorf("codes: listing rendered pages: %w", err)
+	}
+	sort.Strings(matches)
+
+	pages := make([]RasterizePage, 0, len(matches))
+	for _, path := range matches {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		img, _, err := image.Decode(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("codes: decoding rendered page %s: %w", path, err)
+		}
+		pages = append(pages, RasterizePage{Image: img, PointsPerPixel: pointsPerPixel})
+	}
+	return pages, nil
+}
@@ -0,0 +1,49 @@
+package codes
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+)
+
+// DecodeOptions configures a decode run across one uploaded file.
+type DecodeOptions struct {
+	Symbologies  []Symbology
+	MaxPages     int
+	DPI          float64
+	PdftoppmPath string
+}
+
+// Decode reads an uploaded file (an image, or a PDF to rasterize first) and
+// returns every code found, restricted to opts.Symbologies.
+func Decode(data []byte, isPDF bool, opts DecodeOptions) ([]Result, error) {
+	if !isPDF {
+		img, _, err := image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("codes: decoding image: %w", err)
+		}
+		return DecodeImage(img, opts.Symbologies, 0, 1.0)
+	}
+
+	pages, err := RasterizePDF(data, RasterizeOptions{
+		DPI:          opts.DPI,
+		MaxPages:     opts.MaxPages,
+		PdftoppmPath: opts.PdftoppmPath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var all []Result
+	for i, page := range pages {
+		found, err := DecodeImage(page.Image, opts.Symbologies, i+1, page.PointsPerPixel)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, found...)
+	}
+	return all, nil
+}
@@ -0,0 +1,182 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// tokenKind enumerates the lexical classes produced by the lexer.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokName
+	tokString
+	tokHexString
+	tokArrayStart
+	tokArrayEnd
+	tokDictStart
+	tokDictEnd
+	tokKeyword // true, false, null, obj, endobj, R, stream, endstream, xref, trailer, startxref
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// lexer tokenizes a PDF byte buffer. It is not a full PDF tokenizer (it does
+// not need to be for our purposes) but it handles every construct that shows
+// up inside object bodies and cross-reference sections.
+type lexer struct {
+	buf []byte
+	pos int
+}
+
+func newLexer(buf []byte) *lexer {
+	return &lexer{buf: buf}
+}
+
+func isWhitespace(b byte) bool {
+	switch b {
+	case 0x00, 0x09, 0x0A, 0x0C, 0x0D, 0x20:
+		return true
+	}
+	return false
+}
+
+func isDelimiter(b byte) bool {
+	switch b {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func (l *lexer) skipWhitespaceAndComments() {
+	for l.pos < len(l.buf) {
+		b := l.buf[l.pos]
+		if isWhitespace(b) {
+			l.pos++
+			continue
+		}
+		if b == '%' {
+			for l.pos < len(l.buf) && l.buf[l.pos] != '\n' && l.buf[l.pos] != '\r' {
+				l.pos++
+			}
+			continue
+		}
+		break
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	l.skipWhitespaceAndComments()
+	if l.pos >= len(l.buf) {
+		return token{kind: tokEOF}, nil
+	}
+
+	b := l.buf[l.pos]
+	switch {
+	case b == '/':
+		start := l.pos + 1
+		l.pos++
+		for l.pos < len(l.buf) && !isWhitespace(l.buf[l.pos]) && !isDelimiter(l.buf[l.pos]) {
+			l.pos++
+		}
+		return token{kind: tokName, text: string(l.buf[start:l.pos])}, nil
+	case b == '[':
+		l.pos++
+		return token{kind: tokArrayStart}, nil
+	case b == ']':
+		l.pos++
+		return token{kind: tokArrayEnd}, nil
+	case b == '<' && l.pos+1 < len(l.buf) && l.buf[l.pos+1] == '<':
+		l.pos += 2
+		return token{kind: tokDictStart}, nil
+	case b == '>' && l.pos+1 < len(l.buf) && l.buf[l.pos+1] == '>':
+		l.pos += 2
+		return token{kind: tokDictEnd}, nil
+	case b == '<':
+		return l.readHexString()
+	case b == '(':
+		return l.readLiteralString()
+	case b == '+' || b == '-' || b == '.' || (b >= '0' && b <= '9'):
+		return l.readNumber()
+	default:
+		return l.readKeyword()
+	}
+}
+
+func (l *lexer) readHexString() (token, error) {
+	l.pos++ // consume '<'
+	start := l.pos
+	for l.pos < len(l.buf) && l.buf[l.pos] != '>' {
+		l.pos++
+	}
+	if l.pos >= len(l.buf) {
+		return token{}, fmt.Errorf("pdf: unterminated hex string")
+	}
+	text := string(l.buf[start:l.pos])
+	l.pos++ // consume '>'
+	return token{kind: tokHexString, text: text}, nil
+}
+
+func (l *lexer) readLiteralString() (token, error) {
+	l.pos++ // consume '('
+	var out bytes.Buffer
+	depth := 1
+	for l.pos < len(l.buf) {
+		b := l.buf[l.pos]
+		if b == '\\' && l.pos+1 < len(l.buf) {
+			out.WriteByte(b)
+			out.WriteByte(l.buf[l.pos+1])
+			l.pos += 2
+			continue
+		}
+		if b == '(' {
+			depth++
+		} else if b == ')' {
+			depth--
+			if depth == 0 {
+				l.pos++
+				return token{kind: tokString, text: out.String()}, nil
+			}
+		}
+		out.WriteByte(b)
+		l.pos++
+	}
+	return token{}, fmt.Errorf("pdf: unterminated literal string")
+}
+
+func (l *lexer) readNumber() (token, error) {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.buf) {
+		b := l.buf[l.pos]
+		if (b >= '0' && b <= '9') || b == '.' || b == '+' || b == '-' {
+			l.pos++
+			continue
+		}
+		break
+	}
+	return token{kind: tokNumber, text: string(l.buf[start:l.pos])}, nil
+}
+
+func (l *lexer) readKeyword() (token, error) {
+	start := l.pos
+	for l.pos < len(l.buf) && !isWhitespace(l.buf[l.pos]) && !isDelimiter(l.buf[l.pos]) {
+		l.pos++
+	}
+	if l.pos == start {
+		// Unknown delimiter byte we don't special-case (e.g. stray '{').
+		l.pos++
+	}
+	return token{kind: tokKeyword, text: string(l.buf[start:l.pos])}, nil
+}
+
+func parseNumber(text string) (float64, error) {
+	return strconv.ParseFloat(text, 64)
+}
@@ -0,0 +1,325 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// MergeOptions controls Merge's output.
+type MergeOptions struct {
+	WriteOptions
+	// Base, if set, is a previously-merged document that new pages are
+	// appended to instead of starting a fresh document from docs[0]. Base's
+	// objects are carried through with their existing object numbers, and the
+	// other documents' objects are numbered above them; Merge always emits a
+	// standalone, freshly-serialized file, so
+	// Base is about composing page trees, not about producing a true
+	// incremental (byte-preserving, /Prev-chained) update.
+	Base *Document
+}
+
+// Merge combines the page trees of docs (and optionally opts.Base) into a
+// single PDF, renumbering the objects of docs into a fresh, non-overlapping
+// number space (above the numbers Base's objects keep) and rewriting their
+// references accordingly.
+func Merge(docs []*Document, opts MergeOptions) ([]byte, error) {
+	if len(docs) == 0 && opts.Base == nil {
+		return nil, fmt.Errorf("pdf: merge requires at least one document")
+	}
+
+	merged := map[int]*Object{}
+	nextNum := 1
+
+	if opts.Base != nil {
+		// Carry the base file's objects through verbatim, keeping their
+		// existing object numbers (Write renumbers nothing that's already in
+		// merged); new documents' objects are renumbered above this range.
+		for num, entry := range opts.Base.entries {
+			if entry.Type != xrefInUse {
+				continue
+			}
+			obj, err := opts.Base.readIndirectObjectAt(int(entry.Offset))
+			if err != nil {
+				return nil, fmt.Errorf("pdf: reading base object %d: %w", num, err)
+			}
+			merged[num] = obj
+			if num >= nextNum {
+				nextNum = num + 1
+			}
+		}
+	}
+
+	var allPageRefs []Ref
+	if opts.Base != nil {
+		if rootRef, ok := AsRef(opts.Base.Trailer["Root"]); ok {
+			if pages, err := collectPageRefsFromRoot(opts.Base, rootRef); err == nil {
+				allPageRefs = append(allPageRefs, pages...)
+			}
+		}
+	}
+
+	for _, doc := range docs {
+		remap := map[int]int{}
+		rootRef, ok := AsRef(doc.Trailer["Root"])
+		if !ok {
+			return nil, fmt.Errorf("pdf: document has no /Root in trailer")
+		}
+
+		pageRefs, inherited, err := collectPageRefsAndInherited(doc, rootRef)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pageRef := range pageRefs {
+			newRef, err := copyObjectGraph(doc, pageRef, merged, remap, &nextNum)
+			if err != nil {
+				return nil, err
+			}
+			if pageObj, ok := merged[newRef.Num]; ok {
+				if dict, ok := AsDict(pageObj.Value); ok {
+					if err := applyInheritedPageAttrs(doc, dict, inherited[pageRef.Num], merged, remap, &nextNum); err != nil {
+						return nil, err
+					}
+				}
+			}
+			allPageRefs = append(allPageRefs, newRef)
+		}
+	}
+
+	pagesNum := nextNum
+	nextNum++
+	catalogNum := nextNum
+	nextNum++
+
+	kids := make(Array, len(allPageRefs))
+	for i, r := range allPageRefs {
+		// The new /Parent is the merged Pages node; update each page dict.
+		if pageObj, ok := merged[r.Num]; ok {
+			if dict, ok := AsDict(pageObj.Value); ok {
+				dict["Parent"] = Ref{Num: pagesNum, Gen: 0}
+			}
+		}
+		kids[i] = r
+	}
+
+	merged[pagesNum] = &Object{
+		Ref: Ref{Num: pagesNum, Gen: 0},
+		Value: Dict{
+			"Type":  Name("Pages"),
+			"Kids":  kids,
+			"Count": int64(len(kids)),
+		},
+	}
+	merged[catalogNum] = &Object{
+		Ref: Ref{Num: catalogNum, Gen: 0},
+		Value: Dict{
+			"Type":  Name("Catalog"),
+			"Pages": Ref{Num: pagesNum, Gen: 0},
+		},
+	}
+
+	trailer := Dict{
+		"Root": Ref{Num: catalogNum, Gen: 0},
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, merged, trailer, opts.WriteOptions); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// CountPages returns the number of leaf /Page objects reachable from doc's
+// page tree, walking it the same way Merge does.
+func CountPages(doc *Document) (int, error) {
+	rootRef, ok := AsRef(doc.Trailer["Root"])
+	if !ok {
+		return 0, fmt.Errorf("pdf: document has no /Root in trailer")
+	}
+	pageRefs, err := collectPageRefsFromRoot(doc, rootRef)
+	if err != nil {
+		return 0, err
+	}
+	return len(pageRefs), nil
+}
+
+// collectPageRefs walks Root -> Pages -> Kids (recursively, since a Pages
+// node may itself contain Pages nodes) and returns the leaf /Page object refs
+// in document order.
+func collectPageRefs(doc *Document, rootRef Ref) ([]Ref, error) {
+	return collectPageRefsFromRoot(doc, rootRef)
+}
+
+func collectPageRefsFromRoot(doc *Document, rootRef Ref) ([]Ref, error) {
+	refs, _, err := collectPageRefsAndInherited(doc, rootRef)
+	return refs, err
+}
+
+// inheritablePageAttrs are the /Pages-tree dict keys a /Page node may omit
+// and inherit from its nearest ancestor node that sets them (PDF 32000-1
+// Table 29), most commonly /Resources and /MediaBox set once on the tree
+// root instead of repeated on every leaf.
+var inheritablePageAttrs = []string{"Resources", "MediaBox", "CropBox", "Rotate"}
+
+// collectPageRefsAndInherited walks the page tree like collectPageRefsFromRoot,
+// additionally resolving each returned page's inherited attributes from its
+// ancestor /Pages nodes, keyed by the page's own object number.
+func collectPageRefsAndInherited(doc *Document, rootRef Ref) ([]Ref, map[int]Dict, error) {
+	rootObj, err := doc.GetObject(rootRef)
+	if err != nil {
+		return nil, nil, err
+	}
+	rootDict, ok := AsDict(rootObj.Value)
+	if !ok {
+		return nil, nil, fmt.Errorf("pdf: /Root is not a dictionary")
+	}
+	pagesRef, ok := AsRef(rootDict["Pages"])
+	if !ok {
+		return nil, nil, fmt.Errorf("pdf: /Root has no /Pages")
+	}
+	var out []Ref
+	inherited := map[int]Dict{}
+	if err := walkPagesNode(doc, pagesRef, &out, map[int]bool{}, Dict{}, inherited); err != nil {
+		return nil, nil, err
+	}
+	return out, inherited, nil
+}
+
+func walkPagesNode(doc *Document, ref Ref, out *[]Ref, visited map[int]bool, inherited Dict, inheritedOut map[int]Dict) error {
+	if visited[ref.Num] {
+		return nil // guard against malformed/cyclic page trees
+	}
+	visited[ref.Num] = true
+
+	obj, err := doc.GetObject(ref)
+	if err != nil {
+		return err
+	}
+	dict, ok := AsDict(obj.Value)
+	if !ok {
+		return fmt.Errorf("pdf: page tree node %d is not a dictionary", ref.Num)
+	}
+
+	// Copy-on-write: each branch of the tree gets its own inherited set, so a
+	// sibling's override at this level doesn't leak into other siblings.
+	nodeInherited := make(Dict, len(inherited))
+	for k, v := range inherited {
+		nodeInherited[k] = v
+	}
+	for _, k := range inheritablePageAttrs {
+		if v, ok := dict[k]; ok {
+			nodeInherited[k] = v
+		}
+	}
+
+	if t, _ := AsName(dict["Type"]); t == "Page" {
+		*out = append(*out, ref)
+		inheritedOut[ref.Num] = nodeInherited
+		return nil
+	}
+	kids, _ := AsArray(dict["Kids"])
+	for _, kid := range kids {
+		kidRef, ok := AsRef(kid)
+		if !ok {
+			continue
+		}
+		if err := walkPagesNode(doc, kidRef, out, visited, nodeInherited, inheritedOut); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyInheritedPageAttrs fills in any inheritablePageAttrs that dict (a
+// page already copied into merged by copyObjectGraph) doesn't set itself,
+// using the attributes it would have inherited from its source document's
+// page tree. Values that are themselves indirect references are copied into
+// merged the same way the rest of the page's object graph is, so the merged
+// file doesn't depend on the source document.
+func applyInheritedPageAttrs(doc *Document, dict Dict, inherited Dict, merged map[int]*Object, remap map[int]int, nextNum *int) error {
+	for _, k := range inheritablePageAttrs {
+		if _, present := dict[k]; present {
+			continue
+		}
+		v, ok := inherited[k]
+		if !ok {
+			continue
+		}
+		nv, err := remapValue(doc, v, merged, remap, nextNum)
+		if err != nil {
+			return err
+		}
+		dict[k] = nv
+	}
+	return nil
+}
+
+// copyObjectGraph deep-copies the object graph reachable from ref (a single
+// page and everything it points to, following shared resources too) into
+// dst, renumbering every object it touches and reusing remap so repeated
+// references to the same source object land on the same destination object.
+func copyObjectGraph(doc *Document, ref Ref, dst map[int]*Object, remap map[int]int, nextNum *int) (Ref, error) {
+	if newNum, ok := remap[ref.Num]; ok {
+		return Ref{Num: newNum, Gen: 0}, nil
+	}
+
+	newNum := *nextNum
+	*nextNum++
+	remap[ref.Num] = newNum
+
+	obj, err := doc.GetObject(ref)
+	if err != nil {
+		return Ref{}, err
+	}
+
+	newValue, err := remapValue(doc, obj.Value, dst, remap, nextNum)
+	if err != nil {
+		return Ref{}, err
+	}
+	if dict, ok := AsDict(newValue); ok {
+		if t, _ := AsName(dict["Type"]); t == "Page" {
+			// /Parent is rewritten by the caller once the merged Pages node
+			// number is known; drop the stale source-document parent for now.
+			delete(dict, "Parent")
+		}
+	}
+
+	dst[newNum] = &Object{Ref: Ref{Num: newNum, Gen: 0}, Value: newValue}
+	return Ref{Num: newNum, Gen: 0}, nil
+}
+
+func remapValue(doc *Document, v interface{}, dst map[int]*Object, remap map[int]int, nextNum *int) (interface{}, error) {
+	switch t := v.(type) {
+	case Ref:
+		return copyObjectGraph(doc, t, dst, remap, nextNum)
+	case Array:
+		out := make(Array, len(t))
+		for i, item := range t {
+			nv, err := remapValue(doc, item, dst, remap, nextNum)
+			if err != nil {
+				return nil, err
+			}
+			out[i] = nv
+		}
+		return out, nil
+	case Dict:
+		out := make(Dict, len(t))
+		for k, item := range t {
+			nv, err := remapValue(doc, item, dst, remap, nextNum)
+			if err != nil {
+				return nil, err
+			}
+			out[k] = nv
+		}
+		return out, nil
+	case *Stream:
+		newDict, err := remapValue(doc, t.Dict, dst, remap, nextNum)
+		if err != nil {
+			return nil, err
+		}
+		nd, _ := AsDict(newDict)
+		return &Stream{Dict: nd, Data: t.Data}, nil
+	default:
+		return v, nil
+	}
+}
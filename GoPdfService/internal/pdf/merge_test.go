@@ -0,0 +1,124 @@
+package pdf
+
+import (
+	"bytes"
+	"testing"
+)
+
+// buildSingleDoc writes a minimal one-page document: a Pages root carrying
+// /MediaBox and /Resources, and a leaf /Page that relies on inheriting both
+// (neither is set on the page itself), then opens the bytes back up so
+// callers get the same *Document shape Merge sees from an uploaded file.
+func buildSingleDoc(t *testing.T, useXRefStream bool) *Document {
+	t.Helper()
+
+	objects := map[int]*Object{
+		1: {Ref: Ref{Num: 1, Gen: 0}, Value: Dict{
+			"Type":  Name("Catalog"),
+			"Pages": Ref{Num: 2, Gen: 0},
+		}},
+		2: {Ref: Ref{Num: 2, Gen: 0}, Value: Dict{
+			"Type":      Name("Pages"),
+			"Kids":      Array{Ref{Num: 3, Gen: 0}},
+			"Count":     int64(1),
+			"MediaBox":  Array{int64(0), int64(0), int64(612), int64(792)},
+			"Resources": Dict{"Font": Dict{}},
+		}},
+		3: {Ref: Ref{Num: 3, Gen: 0}, Value: Dict{
+			"Type":   Name("Page"),
+			"Parent": Ref{Num: 2, Gen: 0},
+		}},
+	}
+	trailer := Dict{"Root": Ref{Num: 1, Gen: 0}}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, objects, trailer, WriteOptions{UseXRefStream: useXRefStream}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	doc, err := Open(buf.Bytes())
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	return doc
+}
+
+func TestXRefStreamRoundTrip(t *testing.T) {
+	doc := buildSingleDoc(t, true)
+
+	n, err := CountPages(doc)
+	if err != nil {
+		t.Fatalf("CountPages: %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("CountPages = %d, want 1", n)
+	}
+}
+
+func TestMergeProducesReparsableXRefStream(t *testing.T) {
+	doc := buildSingleDoc(t, false)
+
+	out, err := Merge([]*Document{doc}, MergeOptions{WriteOptions: WriteOptions{UseXRefStream: true}})
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	merged, err := Open(out)
+	if err != nil {
+		t.Fatalf("Open(merged): %v", err)
+	}
+
+	n, err := CountPages(merged)
+	if err != nil {
+		t.Fatalf("CountPages(merged): %v", err)
+	}
+	if n != 1 {
+		t.Fatalf("CountPages(merged) = %d, want 1", n)
+	}
+}
+
+func TestMergeInheritsPageAttrs(t *testing.T) {
+	doc := buildSingleDoc(t, false)
+
+	out, err := Merge([]*Document{doc}, MergeOptions{})
+	if err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+
+	merged, err := Open(out)
+	if err != nil {
+		t.Fatalf("Open(merged): %v", err)
+	}
+
+	rootRef, ok := AsRef(merged.Trailer["Root"])
+	if !ok {
+		t.Fatalf("merged trailer has no /Root")
+	}
+	pageRefs, err := CountPages(merged)
+	if err != nil || pageRefs != 1 {
+		t.Fatalf("CountPages(merged) = %d, err %v, want 1", pageRefs, err)
+	}
+
+	refs, _, err := collectPageRefsAndInherited(merged, rootRef)
+	if err != nil {
+		t.Fatalf("collectPageRefsAndInherited: %v", err)
+	}
+	if len(refs) != 1 {
+		t.Fatalf("got %d page refs, want 1", len(refs))
+	}
+
+	pageObj, err := merged.GetObject(refs[0])
+	if err != nil {
+		t.Fatalf("GetObject(page): %v", err)
+	}
+	pageDict, ok := AsDict(pageObj.Value)
+	if !ok {
+		t.Fatalf("merged page is not a dict")
+	}
+	if _, ok := pageDict["MediaBox"]; !ok {
+		t.Fatalf("merged page is missing inherited /MediaBox")
+	}
+	if _, ok := pageDict["Resources"]; !ok {
+		t.Fatalf("merged page is missing inherited /Resources")
+	}
+}
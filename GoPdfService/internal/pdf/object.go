@@ -0,0 +1,74 @@
+// Package pdf implements a minimal PDF object model, parser and writer that
+// is sufficient to merge PDF documents by rewriting their cross-reference
+// tables instead of treating PDFs as opaque byte blobs.
+package pdf
+
+import "fmt"
+
+// Ref identifies an indirect object by number and generation, e.g. "12 0 R".
+type Ref struct {
+	Num int
+	Gen int
+}
+
+func (r Ref) String() string {
+	return fmt.Sprintf("%d %d R", r.Num, r.Gen)
+}
+
+// Name is a PDF name object such as /Type or /Pages (without the slash).
+type Name string
+
+// HexString is a PDF hex string object, written as <48656C6C6F>. It is kept
+// distinct from string (which round-trips as a literal string in
+// parentheses) because signature /Contents values must be emitted as hex.
+type HexString string
+
+// Dict is a PDF dictionary. Values may be Name, Ref, string, int64, float64,
+// bool, Array, Dict, *Stream or nil.
+type Dict map[string]interface{}
+
+// Array is a PDF array object.
+type Array []interface{}
+
+// Stream pairs a dictionary with its (still encoded) byte content.
+type Stream struct {
+	Dict Dict
+	Data []byte
+}
+
+// Object is an indirect object as found in a PDF file: a number/generation
+// pair plus its value.
+type Object struct {
+	Ref   Ref
+	Value interface{}
+}
+
+// AsDict returns v as a Dict, unwrapping *Stream if necessary.
+func AsDict(v interface{}) (Dict, bool) {
+	switch t := v.(type) {
+	case Dict:
+		return t, true
+	case *Stream:
+		return t.Dict, true
+	default:
+		return nil, false
+	}
+}
+
+// AsRef returns v as a Ref.
+func AsRef(v interface{}) (Ref, bool) {
+	r, ok := v.(Ref)
+	return r, ok
+}
+
+// AsName returns v as a Name.
+func AsName(v interface{}) (Name, bool) {
+	n, ok := v.(Name)
+	return n, ok
+}
+
+// AsArray returns v as an Array.
+func AsArray(v interface{}) (Array, bool) {
+	a, ok := v.(Array)
+	return a, ok
+}
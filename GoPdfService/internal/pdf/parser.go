@@ -0,0 +1,190 @@
+package pdf
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+// parser turns a token stream into PDF object values. It peeks ahead by up to
+// two tokens to disambiguate "12 0 R" (a reference) from a bare number
+// followed by an unrelated token.
+type parser struct {
+	lex  *lexer
+	toks []token // lookahead buffer
+}
+
+func newParser(buf []byte, pos int) *parser {
+	l := newLexer(buf)
+	l.pos = pos
+	return &parser{lex: l}
+}
+
+func (p *parser) peek(n int) (token, error) {
+	for len(p.toks) <= n {
+		t, err := p.lex.next()
+		if err != nil {
+			return token{}, err
+		}
+		p.toks = append(p.toks, t)
+	}
+	return p.toks[n], nil
+}
+
+func (p *parser) pop() (token, error) {
+	t, err := p.peek(0)
+	if err != nil {
+		return token{}, err
+	}
+	p.toks = p.toks[1:]
+	return t, nil
+}
+
+// parseValue parses a single PDF value starting at the current position.
+func (p *parser) parseValue() (interface{}, error) {
+	t, err := p.pop()
+	if err != nil {
+		return nil, err
+	}
+	switch t.kind {
+	case tokEOF:
+		return nil, fmt.Errorf("pdf: unexpected eof while parsing value")
+	case tokName:
+		return Name(t.text), nil
+	case tokHexString:
+		decoded, err := hex.DecodeString(padOddHex(t.text))
+		if err != nil {
+			return nil, fmt.Errorf("pdf: bad hex string: %w", err)
+		}
+		return string(decoded), nil
+	case tokString:
+		return unescapeLiteral(t.text), nil
+	case tokArrayStart:
+		var arr Array
+		for {
+			next, err := p.peek(0)
+			if err != nil {
+				return nil, err
+			}
+			if next.kind == tokArrayEnd {
+				p.pop()
+				break
+			}
+			v, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		return arr, nil
+	case tokDictStart:
+		dict := Dict{}
+		for {
+			next, err := p.peek(0)
+			if err != nil {
+				return nil, err
+			}
+			if next.kind == tokDictEnd {
+				p.pop()
+				break
+			}
+			keyTok, err := p.pop()
+			if err != nil {
+				return nil, err
+			}
+			if keyTok.kind != tokName {
+				return nil, fmt.Errorf("pdf: expected name key in dict, got %q", keyTok.text)
+			}
+			v, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			dict[keyTok.text] = v
+		}
+		// A dictionary may be followed by "stream" keyword making it a stream
+		// object; the caller (readIndirectObject) handles that since it needs
+		// raw byte offsets.
+		return dict, nil
+	case tokNumber:
+		// Could be a plain number, or the start of "num gen R".
+		second, err := p.peek(0)
+		if err == nil && second.kind == tokNumber {
+			third, err := p.peek(1)
+			if err == nil && third.kind == tokKeyword && third.text == "R" {
+				num, _ := strconv.Atoi(t.text)
+				gen, _ := strconv.Atoi(second.text)
+				p.pop()
+				p.pop()
+				return Ref{Num: num, Gen: gen}, nil
+			}
+		}
+		if isInteger(t.text) {
+			n, _ := strconv.ParseInt(t.text, 10, 64)
+			return n, nil
+		}
+		f, err := parseNumber(t.text)
+		if err != nil {
+			return nil, fmt.Errorf("pdf: bad number %q: %w", t.text, err)
+		}
+		return f, nil
+	case tokKeyword:
+		switch t.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("pdf: unexpected keyword %q", t.text)
+		}
+	default:
+		return nil, fmt.Errorf("pdf: unexpected token kind %d", t.kind)
+	}
+}
+
+func isInteger(s string) bool {
+	for i, r := range s {
+		if r == '+' || r == '-' {
+			if i != 0 {
+				return false
+			}
+			continue
+		}
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return len(s) > 0
+}
+
+func padOddHex(s string) string {
+	if len(s)%2 == 1 {
+		return s + "0"
+	}
+	return s
+}
+
+func unescapeLiteral(s string) string {
+	out := make([]byte, 0, len(s))
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			out = append(out, s[i])
+			continue
+		}
+		switch s[i+1] {
+		case 'n':
+			out = append(out, '\n')
+		case 'r':
+			out = append(out, '\r')
+		case 't':
+			out = append(out, '\t')
+		case '(', ')', '\\':
+			out = append(out, s[i+1])
+		default:
+			out = append(out, s[i+1])
+		}
+		i++
+	}
+	return string(out)
+}
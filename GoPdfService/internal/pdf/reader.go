@@ -0,0 +1,586 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Document is a parsed PDF file: its raw bytes plus a flattened table mapping
+// every live object number to where it can be read from, after following the
+// full /Prev chain of incremental updates.
+type Document struct {
+	raw     []byte
+	entries map[int]xrefEntry
+	Trailer Dict
+	cache   map[int]*Object
+}
+
+// Open parses buf as a PDF file, resolving the xref table (classic or
+// cross-reference stream) and following /Prev links so that the final
+// trailer and entry table reflect every incremental update in the chain.
+func Open(buf []byte) (*Document, error) {
+	doc := &Document{raw: buf, entries: map[int]xrefEntry{}, cache: map[int]*Object{}}
+
+	startOffset, err := findStartXref(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[int64]bool{}
+	offset := startOffset
+	var trailer Dict
+	for offset != -1 {
+		if seen[offset] {
+			break // cyclical /Prev chain; tolerate and stop.
+		}
+		seen[offset] = true
+
+		sectionTrailer, prev, err := doc.readXRefSection(offset)
+		if err != nil {
+			return nil, fmt.Errorf("pdf: reading xref at offset %d: %w", offset, err)
+		}
+		if trailer == nil {
+			trailer = sectionTrailer
+		} else {
+			// Later (newer) sections were merged first; earlier /Prev
+			// sections only fill in keys the newer trailer didn't set
+			// (e.g. /Root carried forward, /Info from the original file).
+			for k, v := range sectionTrailer {
+				if _, exists := trailer[k]; !exists {
+					trailer[k] = v
+				}
+			}
+		}
+		offset = prev
+	}
+
+	if trailer == nil {
+		return nil, fmt.Errorf("pdf: no trailer found")
+	}
+	doc.Trailer = trailer
+	return doc, nil
+}
+
+func findStartXref(buf []byte) (int64, error) {
+	tail := buf
+	if len(tail) > 2048 {
+		tail = tail[len(tail)-2048:]
+	}
+	idx := bytes.LastIndex(tail, []byte("startxref"))
+	if idx == -1 {
+		return 0, fmt.Errorf("pdf: missing startxref")
+	}
+	p := newParser(tail, idx+len("startxref"))
+	t, err := p.pop()
+	if err != nil {
+		return 0, err
+	}
+	if t.kind != tokNumber {
+		return 0, fmt.Errorf("pdf: startxref not followed by a number")
+	}
+	n, err := strconv.ParseInt(t.text, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// readXRefSection reads one xref section (classic table+trailer, or a single
+// cross-reference stream object) at byte offset off, merging newly-seen
+// entries into doc.entries (entries already present take precedence, since
+// the most recent revision is always processed first). It returns that
+// section's trailer dictionary and the /Prev offset, or -1 if there is none.
+func (doc *Document) readXRefSection(off int64) (Dict, int64, error) {
+	p := newParser(doc.raw, int(off))
+	t, err := p.peek(0)
+	if err != nil {
+		return nil, -1, err
+	}
+	if t.kind == tokKeyword && t.text == "xref" {
+		return doc.readClassicXRef(p)
+	}
+	return doc.readXRefStream(int(off))
+}
+
+func (doc *Document) readClassicXRef(p *parser) (Dict, int64, error) {
+	p.pop() // consume "xref"
+	for {
+		t, err := p.peek(0)
+		if err != nil {
+			return nil, -1, err
+		}
+		if t.kind == tokKeyword && t.text == "trailer" {
+			p.pop()
+			break
+		}
+		startTok, err := p.pop()
+		if err != nil || startTok.kind != tokNumber {
+			return nil, -1, fmt.Errorf("pdf: malformed xref subsection header")
+		}
+		countTok, err := p.pop()
+		if err != nil || countTok.kind != tokNumber {
+			return nil, -1, fmt.Errorf("pdf: malformed xref subsection header")
+		}
+		start, _ := strconv.Atoi(startTok.text)
+		count, _ := strconv.Atoi(countTok.text)
+		for i := 0; i < count; i++ {
+			offTok, err := p.pop()
+			genTok, err2 := p.pop()
+			kindTok, err3 := p.pop()
+			if err != nil || err2 != nil || err3 != nil {
+				return nil, -1, fmt.Errorf("pdf: malformed xref row")
+			}
+			objNum := start + i
+			if _, exists := doc.entries[objNum]; exists {
+				continue
+			}
+			offset, _ := strconv.ParseInt(offTok.text, 10, 64)
+			gen, _ := strconv.Atoi(genTok.text)
+			switch kindTok.text {
+			case "n":
+				doc.entries[objNum] = xrefEntry{Type: xrefInUse, Offset: offset, Gen: gen}
+			case "f":
+				doc.entries[objNum] = xrefEntry{Type: xrefFree}
+			}
+		}
+	}
+
+	trailerVal, err := p.parseValue()
+	if err != nil {
+		return nil, -1, fmt.Errorf("pdf: parsing trailer dict: %w", err)
+	}
+	trailer, ok := trailerVal.(Dict)
+	if !ok {
+		return nil, -1, fmt.Errorf("pdf: trailer is not a dictionary")
+	}
+	prev := int64(-1)
+	if xrefStmRef, ok := trailer["XRefStm"]; ok {
+		// Hybrid-reference file: classic table plus a companion xref stream
+		// carrying entries for compressed objects. Merge it too.
+		if n, ok := asInt64(xrefStmRef); ok {
+			if _, _, err := doc.readXRefStream(int(n)); err != nil {
+				return nil, -1, err
+			}
+		}
+	}
+	if p, ok := trailer["Prev"]; ok {
+		if n, ok := asInt64(p); ok {
+			prev = n
+		}
+	}
+	return trailer, prev, nil
+}
+
+func (doc *Document) readXRefStream(off int) (Dict, int64, error) {
+	obj, err := doc.readIndirectObjectAt(off)
+	if err != nil {
+		return nil, -1, err
+	}
+	stream, ok := obj.Value.(*Stream)
+	if !ok {
+		return nil, -1, fmt.Errorf("pdf: expected xref stream object at offset %d", off)
+	}
+	dict := stream.Dict
+
+	wArr, ok := AsArray(dict["W"])
+	if !ok || len(wArr) != 3 {
+		return nil, -1, fmt.Errorf("pdf: xref stream missing valid /W")
+	}
+	w := [3]int{intOf(wArr[0]), intOf(wArr[1]), intOf(wArr[2])}
+
+	size := intOf(dict["Size"])
+	var index []int
+	if idxArr, ok := AsArray(dict["Index"]); ok {
+		for _, v := range idxArr {
+			index = append(index, intOf(v))
+		}
+	} else {
+		index = []int{0, size}
+	}
+
+	data, err := decodeStreamData(stream)
+	if err != nil {
+		return nil, -1, fmt.Errorf("pdf: decoding xref stream: %w", err)
+	}
+
+	rowLen := w[0] + w[1] + w[2]
+	pos := 0
+	for i := 0; i+1 < len(index); i += 2 {
+		start, count := index[i], index[i+1]
+		for j := 0; j < count; j++ {
+			if pos+rowLen > len(data) {
+				return nil, -1, fmt.Errorf("pdf: xref stream truncated")
+			}
+			row := data[pos : pos+rowLen]
+			pos += rowLen
+
+			fieldType := int64(xrefInUse)
+			if w[0] > 0 {
+				fieldType = beInt(row[0:w[0]])
+			}
+			field2 := beInt(row[w[0] : w[0]+w[1]])
+			field3 := beInt(row[w[0]+w[1] : rowLen])
+
+			objNum := start + j
+			if _, exists := doc.entries[objNum]; exists {
+				continue
+			}
+			switch xrefEntryType(fieldType) {
+			case xrefFree:
+				doc.entries[objNum] = xrefEntry{Type: xrefFree}
+			case xrefInUse:
+				doc.entries[objNum] = xrefEntry{Type: xrefInUse, Offset: field2, Gen: int(field3)}
+			case xrefCompressed:
+				doc.entries[objNum] = xrefEntry{Type: xrefCompressed, Offset: field2, Gen: int(field3)}
+			}
+		}
+	}
+
+	prev := int64(-1)
+	if p, ok := dict["Prev"]; ok {
+		if n, ok := asInt64(p); ok {
+			prev = n
+		}
+	}
+	return dict, prev, nil
+}
+
+func beInt(b []byte) int64 {
+	var n int64
+	for _, c := range b {
+		n = n<<8 | int64(c)
+	}
+	return n
+}
+
+func intOf(v interface{}) int {
+	n, _ := asInt64(v)
+	return int(n)
+}
+
+func asInt64(v interface{}) (int64, bool) {
+	switch t := v.(type) {
+	case int64:
+		return t, true
+	case float64:
+		return int64(t), true
+	default:
+		return 0, false
+	}
+}
+
+// GetObject resolves an indirect reference, transparently pulling objects
+// out of object streams when the xref table says they are compressed.
+func (doc *Document) GetObject(ref Ref) (*Object, error) {
+	if cached, ok := doc.cache[ref.Num]; ok {
+		return cached, nil
+	}
+	entry, ok := doc.entries[ref.Num]
+	if !ok || entry.Type == xrefFree {
+		return &Object{Ref: ref, Value: nil}, nil
+	}
+	var obj *Object
+	var err error
+	switch entry.Type {
+	case xrefInUse:
+		obj, err = doc.readIndirectObjectAt(int(entry.Offset))
+	case xrefCompressed:
+		obj, err = doc.readCompressedObject(ref.Num, int(entry.Offset), entry.Gen)
+	default:
+		return nil, fmt.Errorf("pdf: unsupported xref entry type for object %d", ref.Num)
+	}
+	if err != nil {
+		return nil, err
+	}
+	doc.cache[ref.Num] = obj
+	return obj, nil
+}
+
+// MaxObjectNumber returns the highest object number known to this document's
+// xref table, for callers (like incremental signing) that need to allocate
+// fresh object numbers above every existing one.
+func (doc *Document) MaxObjectNumber() int {
+	max := 0
+	for num := range doc.entries {
+		if num > max {
+			max = num
+		}
+	}
+	return max
+}
+
+// Resolve dereferences v if it is a Ref, returning its underlying value.
+func (doc *Document) Resolve(v interface{}) (interface{}, error) {
+	ref, ok := AsRef(v)
+	if !ok {
+		return v, nil
+	}
+	obj, err := doc.GetObject(ref)
+	if err != nil {
+		return nil, err
+	}
+	return obj.Value, nil
+}
+
+func (doc *Document) readIndirectObjectAt(off int) (*Object, error) {
+	p := newParser(doc.raw, off)
+	numTok, err := p.pop()
+	if err != nil {
+		return nil, err
+	}
+	genTok, err := p.pop()
+	if err != nil {
+		return nil, err
+	}
+	objTok, err := p.pop()
+	if err != nil || objTok.kind != tokKeyword || objTok.text != "obj" {
+		return nil, fmt.Errorf("pdf: expected 'obj' keyword at offset %d", off)
+	}
+	num, _ := strconv.Atoi(numTok.text)
+	gen, _ := strconv.Atoi(genTok.text)
+
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// Peek for "stream" keyword to upgrade a Dict into a *Stream.
+	next, err := p.peek(0)
+	if err == nil && next.kind == tokKeyword && next.text == "stream" {
+		p.pop()
+		dict, _ := AsDict(value)
+		data, newPos, err := readStreamBody(doc.raw, p.lex.pos, dict)
+		if err != nil {
+			return nil, err
+		}
+		p.lex.pos = newPos
+		p.toks = nil
+		value = &Stream{Dict: dict, Data: data}
+	}
+
+	return &Object{Ref: Ref{Num: num, Gen: gen}, Value: value}, nil
+}
+
+// readStreamBody reads the raw (still-encoded) bytes of a stream given the
+// position right after the "stream" keyword, per the spec: a CRLF or LF
+// follows the keyword, then exactly /Length bytes, then "endstream".
+func readStreamBody(buf []byte, pos int, dict Dict) ([]byte, int, error) {
+	if pos < len(buf) && buf[pos] == '\r' {
+		pos++
+	}
+	if pos < len(buf) && buf[pos] == '\n' {
+		pos++
+	}
+	length, ok := asInt64(dict["Length"])
+	if !ok {
+		// /Length as an indirect reference can't be resolved here without a
+		// document handle; fall back to scanning for "endstream".
+		idx := bytes.Index(buf[pos:], []byte("endstream"))
+		if idx == -1 {
+			return nil, 0, fmt.Errorf("pdf: stream missing /Length and no endstream found")
+		}
+		data := buf[pos : pos+idx]
+		return data, pos + idx + len("endstream"), nil
+	}
+	end := pos + int(length)
+	if end > len(buf) {
+		return nil, 0, fmt.Errorf("pdf: stream /Length overruns file")
+	}
+	data := buf[pos:end]
+	return data, end, nil
+}
+
+// readCompressedObject extracts object objNum from the object stream with
+// number streamNum, at the given index within that stream.
+func (doc *Document) readCompressedObject(objNum, streamNum, index int) (*Object, error) {
+	streamEntry, ok := doc.entries[streamNum]
+	if !ok {
+		return nil, fmt.Errorf("pdf: object stream %d not found", streamNum)
+	}
+	containerObj, err := doc.readIndirectObjectAt(int(streamEntry.Offset))
+	if err != nil {
+		return nil, err
+	}
+	stream, ok := containerObj.Value.(*Stream)
+	if !ok {
+		return nil, fmt.Errorf("pdf: object %d is not an object stream", streamNum)
+	}
+	data, err := decodeStreamData(stream)
+	if err != nil {
+		return nil, err
+	}
+	n := intOf(stream.Dict["N"])
+	first := intOf(stream.Dict["First"])
+
+	headerParser := &parser{lex: newLexer(data)}
+	offsets := make([]int, n)
+	nums := make([]int, n)
+	for i := 0; i < n; i++ {
+		numTok, err := headerParser.pop()
+		if err != nil {
+			return nil, err
+		}
+		offTok, err := headerParser.pop()
+		if err != nil {
+			return nil, err
+		}
+		num, _ := strconv.Atoi(numTok.text)
+		off, _ := strconv.Atoi(offTok.text)
+		nums[i] = num
+		offsets[i] = off
+	}
+	if index >= n {
+		return nil, fmt.Errorf("pdf: compressed object index %d out of range in stream %d", index, streamNum)
+	}
+
+	bodyParser := newParser(data, first+offsets[index])
+	value, err := bodyParser.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	return &Object{Ref: Ref{Num: objNum, Gen: 0}, Value: value}, nil
+}
+
+func decodeStreamData(s *Stream) ([]byte, error) {
+	filter, _ := s.Dict["Filter"]
+	switch f := filter.(type) {
+	case Name:
+		if f == "FlateDecode" {
+			data, err := inflate(s.Data)
+			if err != nil {
+				return nil, err
+			}
+			return applyPredictor(data, asDictOrNil(s.Dict["DecodeParms"]))
+		}
+		return s.Data, nil
+	case Array:
+		data := s.Data
+		parmsArr, _ := AsArray(s.Dict["DecodeParms"])
+		for i, item := range f {
+			if n, ok := AsName(item); ok && n == "FlateDecode" {
+				var err error
+				data, err = inflate(data)
+				if err != nil {
+					return nil, err
+				}
+				var parms Dict
+				if i < len(parmsArr) {
+					parms = asDictOrNil(parmsArr[i])
+				}
+				data, err = applyPredictor(data, parms)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+		return data, nil
+	default:
+		return s.Data, nil
+	}
+}
+
+func inflate(data []byte) ([]byte, error) {
+	r, err := zlib.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+func asDictOrNil(v interface{}) Dict {
+	d, _ := AsDict(v)
+	return d
+}
+
+// applyPredictor reverses the PNG predictor (/Predictor >= 10) that
+// /DecodeParms may layer on top of FlateDecode, as virtually every
+// real-world PDF 1.5 xref stream and compressed object stream does. Each row
+// is prefixed with a filter-type tag byte (0 None, 1 Sub, 2 Up, 3 Average, 4
+// Paeth) that can vary row to row, so it must be read back out per row
+// rather than assumed from /Predictor's single value. Predictor < 10 (no
+// predictor, or the TIFF predictor) passes data through unchanged.
+func applyPredictor(data []byte, parms Dict) ([]byte, error) {
+	if parms == nil {
+		return data, nil
+	}
+	predictor := intWithDefault(parms["Predictor"], 1)
+	if predictor < 10 {
+		return data, nil
+	}
+
+	colors := intWithDefault(parms["Colors"], 1)
+	bpc := intWithDefault(parms["BitsPerComponent"], 8)
+	columns := intWithDefault(parms["Columns"], 1)
+
+	bytesPerPixel := (colors*bpc + 7) / 8
+	if bytesPerPixel < 1 {
+		bytesPerPixel = 1
+	}
+	rowBytes := (colors*bpc*columns + 7) / 8
+
+	var out bytes.Buffer
+	prev := make([]byte, rowBytes)
+	for pos := 0; pos+1+rowBytes <= len(data); pos += 1 + rowBytes {
+		tag := data[pos]
+		row := make([]byte, rowBytes)
+		copy(row, data[pos+1:pos+1+rowBytes])
+
+		for i := 0; i < rowBytes; i++ {
+			var a, b, c byte
+			if i >= bytesPerPixel {
+				a = row[i-bytesPerPixel]
+				c = prev[i-bytesPerPixel]
+			}
+			b = prev[i]
+			switch tag {
+			case 0: // None
+			case 1: // Sub
+				row[i] += a
+			case 2: // Up
+				row[i] += b
+			case 3: // Average
+				row[i] += byte((int(a) + int(b)) / 2)
+			case 4: // Paeth
+				row[i] += paethPredictor(a, b, c)
+			default:
+				return nil, fmt.Errorf("pdf: unsupported PNG predictor tag %d", tag)
+			}
+		}
+
+		out.Write(row)
+		prev = row
+	}
+	return out.Bytes(), nil
+}
+
+func paethPredictor(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := absInt(p-int(a)), absInt(p-int(b)), absInt(p-int(c))
+	switch {
+	case pa <= pb && pa <= pc:
+		return a
+	case pb <= pc:
+		return b
+	default:
+		return c
+	}
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func intWithDefault(v interface{}, def int) int {
+	n, ok := asInt64(v)
+	if !ok {
+		return def
+	}
+	return int(n)
+}
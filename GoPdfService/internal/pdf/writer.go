@@ -0,0 +1,308 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"sort"
+)
+
+// WriteOptions controls how a merged document is serialized.
+type WriteOptions struct {
+	// UseXRefStream selects a PDF 1.5 cross-reference stream instead of a
+	// classic xref table + trailer. Incremental-update consumers understand
+	// either; we choose per request so round-tripping through repeated
+	// merges can match whatever the caller asked for.
+	UseXRefStream bool
+}
+
+// Write serializes objects (keyed by object number) plus trailer (which must
+// at least carry /Root) as a complete, standalone PDF file.
+func Write(w *bytes.Buffer, objects map[int]*Object, trailer Dict, opts WriteOptions) error {
+	w.WriteString("%PDF-1.7\n%\xE2\xE3\xCF\xD3\n")
+
+	nums := make([]int, 0, len(objects))
+	maxNum := 0
+	for n := range objects {
+		nums = append(nums, n)
+		if n > maxNum {
+			maxNum = n
+		}
+	}
+	sort.Ints(nums)
+
+	offsets := make(map[int]int64, len(objects))
+	for _, num := range nums {
+		offsets[num] = int64(w.Len())
+		obj := objects[num]
+		if err := writeIndirectObject(w, obj); err != nil {
+			return err
+		}
+	}
+
+	if opts.UseXRefStream {
+		return writeXRefStream(w, nums, offsets, maxNum, trailer)
+	}
+	return writeClassicXRef(w, nums, offsets, maxNum, trailer)
+}
+
+// WriteIndirectObject serializes a single "N G obj ... endobj" object, for
+// callers (like the signature package) that append objects to an existing
+// file one at a time instead of writing a whole document via Write.
+func WriteIndirectObject(w *bytes.Buffer, obj *Object) error {
+	return writeIndirectObject(w, obj)
+}
+
+func writeIndirectObject(w *bytes.Buffer, obj *Object) error {
+	fmt.Fprintf(w, "%d %d obj\n", obj.Ref.Num, obj.Ref.Gen)
+	if stream, ok := obj.Value.(*Stream); ok {
+		if err := writeValue(w, stream.Dict); err != nil {
+			return err
+		}
+		w.WriteString("\nstream\n")
+		w.Write(stream.Data)
+		w.WriteString("\nendstream\n")
+	} else {
+		if err := writeValue(w, obj.Value); err != nil {
+			return err
+		}
+		w.WriteString("\n")
+	}
+	w.WriteString("endobj\n")
+	return nil
+}
+
+func writeValue(w *bytes.Buffer, v interface{}) error {
+	switch t := v.(type) {
+	case nil:
+		w.WriteString("null")
+	case bool:
+		if t {
+			w.WriteString("true")
+		} else {
+			w.WriteString("false")
+		}
+	case int:
+		fmt.Fprintf(w, "%d", t)
+	case int64:
+		fmt.Fprintf(w, "%d", t)
+	case float64:
+		fmt.Fprintf(w, "%g", t)
+	case Name:
+		fmt.Fprintf(w, "/%s", string(t))
+	case string:
+		w.WriteByte('(')
+		w.WriteString(escapeLiteral(t))
+		w.WriteByte(')')
+	case HexString:
+		w.WriteByte('<')
+		w.WriteString(string(t))
+		w.WriteByte('>')
+	case Ref:
+		fmt.Fprintf(w, "%d %d R", t.Num, t.Gen)
+	case Array:
+		w.WriteByte('[')
+		for i, item := range t {
+			if i > 0 {
+				w.WriteByte(' ')
+			}
+			if err := writeValue(w, item); err != nil {
+				return err
+			}
+		}
+		w.WriteByte(']')
+	case Dict:
+		w.WriteString("<<")
+		keys := make([]string, 0, len(t))
+		for k := range t {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(w, "/%s ", k)
+			if err := writeValue(w, t[k]); err != nil {
+				return err
+			}
+			w.WriteByte(' ')
+		}
+		w.WriteString(">>")
+	default:
+		return fmt.Errorf("pdf: cannot serialize value of type %T", v)
+	}
+	return nil
+}
+
+func escapeLiteral(s string) string {
+	var out bytes.Buffer
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(', ')', '\\':
+			out.WriteByte('\\')
+			out.WriteByte(s[i])
+		default:
+			out.WriteByte(s[i])
+		}
+	}
+	return out.String()
+}
+
+// WriteIncrementalXRefTable appends a classic (sparse) xref section that
+// only lists the object numbers present in offsets, plus the caller's
+// trailer with /Size set. The caller puts /Prev in trailer so the section
+// chains onto whatever xref section came before it. This is what
+// an incremental PDF update (e.g. adding a signature without touching a
+// single byte of the original file) needs instead of the full-file Write.
+//
+// baseOffset is the length of the original file this update is appended to;
+// w holds only the appended bytes written so far, so the xref section's own
+// file offset (what startxref must point at) is baseOffset + w.Len(), not
+// w.Len() alone.
+func WriteIncrementalXRefTable(w *bytes.Buffer, offsets map[int]int64, prevMaxObjNum int, trailer Dict, baseOffset int64) error {
+	nums := make([]int, 0, len(offsets))
+	for n := range offsets {
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+
+	xrefOffset := baseOffset + int64(w.Len())
+	w.WriteString("xref\n")
+
+	size := prevMaxObjNum + 1
+	for i := 0; i < len(nums); {
+		start := nums[i]
+		j := i
+		for j+1 < len(nums) && nums[j+1] == nums[j]+1 {
+			j++
+		}
+		fmt.Fprintf(w, "%d %d\n", start, nums[j]-start+1)
+		for n := start; n <= nums[j]; n++ {
+			fmt.Fprintf(w, "%010d %05d n \n", offsets[n], 0)
+			if n+1 > size {
+				size = n + 1
+			}
+		}
+		i = j + 1
+	}
+
+	trailer["Size"] = int64(size)
+	w.WriteString("trailer\n")
+	if err := writeValue(w, trailer); err != nil {
+		return err
+	}
+	w.WriteString("\nstartxref\n")
+	fmt.Fprintf(w, "%d\n", xrefOffset)
+	w.WriteString("%%EOF\n")
+	return nil
+}
+
+func writeClassicXRef(w *bytes.Buffer, nums []int, offsets map[int]int64, maxNum int, trailer Dict) error {
+	xrefOffset := int64(w.Len())
+	w.WriteString("xref\n")
+	fmt.Fprintf(w, "0 %d\n", maxNum+1)
+	w.WriteString("0000000000 65535 f \n")
+	have := make(map[int]bool, len(nums))
+	for _, n := range nums {
+		have[n] = true
+	}
+	for n := 1; n <= maxNum; n++ {
+		if have[n] {
+			fmt.Fprintf(w, "%010d %05d n \n", offsets[n], 0)
+		} else {
+			w.WriteString("0000000000 65535 f \n")
+		}
+	}
+
+	trailer["Size"] = int64(maxNum + 1)
+	w.WriteString("trailer\n")
+	if err := writeValue(w, trailer); err != nil {
+		return err
+	}
+	w.WriteString("\nstartxref\n")
+	fmt.Fprintf(w, "%d\n", xrefOffset)
+	w.WriteString("%%EOF\n")
+	return nil
+}
+
+// writeXRefStream emits the cross-reference table as a PDF 1.5 stream object
+// instead of a classic table, using fixed field widths (W [1 4 2]) whatever
+// the data: 1 byte for the type, 4 for offsets/object-stream numbers (files
+// over 4 GiB are out of scope), 2 for generation numbers.
+func writeXRefStream(w *bytes.Buffer, nums []int, offsets map[int]int64, maxNum int, trailer Dict) error {
+	have := make(map[int]bool, len(nums))
+	for _, n := range nums {
+		have[n] = true
+	}
+
+	// The xref stream is itself an indirect object and needs its own entry,
+	// so its object number and file offset (known now, since it's written
+	// at the current end of the buffer) are included in the rows below.
+	xrefObjNum := maxNum + 1
+	xrefOffset := int64(w.Len())
+
+	w1, w2, w3 := 1, 4, 2
+	var rows bytes.Buffer
+	for n := 0; n <= xrefObjNum; n++ {
+		switch {
+		case n == 0:
+			writeRow(&rows, 0, 0, 65535, w1, w2, w3)
+		case n == xrefObjNum:
+			writeRow(&rows, 1, xrefOffset, 0, w1, w2, w3)
+		case have[n]:
+			writeRow(&rows, 1, offsets[n], 0, w1, w2, w3)
+		default:
+			writeRow(&rows, 0, 0, 0, w1, w2, w3)
+		}
+	}
+
+	compressed, err := deflate(rows.Bytes())
+	if err != nil {
+		return err
+	}
+
+	dict := Dict{}
+	for k, v := range trailer {
+		dict[k] = v
+	}
+	dict["Type"] = Name("XRef")
+	dict["Size"] = int64(xrefObjNum + 1)
+	dict["W"] = Array{int64(w1), int64(w2), int64(w3)}
+	dict["Filter"] = Name("FlateDecode")
+	dict["Length"] = int64(len(compressed))
+
+	stream := &Stream{Dict: dict, Data: compressed}
+	if err := writeIndirectObject(w, &Object{Ref: Ref{Num: xrefObjNum, Gen: 0}, Value: stream}); err != nil {
+		return err
+	}
+
+	w.WriteString("startxref\n")
+	fmt.Fprintf(w, "%d\n", xrefOffset)
+	w.WriteString("%%EOF\n")
+	return nil
+}
+
+func writeRow(buf *bytes.Buffer, typ int64, f2 int64, f3 int64, w1, w2, w3 int) {
+	writeBE(buf, typ, w1)
+	writeBE(buf, f2, w2)
+	writeBE(buf, f3, w3)
+}
+
+func writeBE(buf *bytes.Buffer, v int64, width int) {
+	b := make([]byte, width)
+	for i := width - 1; i >= 0; i-- {
+		b[i] = byte(v & 0xFF)
+		v >>= 8
+	}
+	buf.Write(b)
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	if _, err := zw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,25 @@
+package pdf
+
+// xrefEntryType mirrors the "type" column of a PDF 1.5 cross-reference
+// stream: 0 = free, 1 = in use (offset based, classic table), 2 = compressed
+// (stored inside an object stream).
+type xrefEntryType int
+
+const (
+	xrefFree       xrefEntryType = 0
+	xrefInUse      xrefEntryType = 1
+	xrefCompressed xrefEntryType = 2
+)
+
+// xrefEntry is the decoded form of one row of either a classic xref table or
+// a cross-reference stream, normalized to the same (type, offset, gen)
+// triple the spec uses internally for stream-based tables.
+type xrefEntry struct {
+	Type xrefEntryType
+	// Offset is the byte offset of the object for type 1, or the object
+	// number of the containing object stream for type 2.
+	Offset int64
+	// Gen is the generation number for type 1, or the index within the
+	// containing object stream for type 2.
+	Gen int
+}
@@ -0,0 +1,295 @@
+package signature
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"time"
+)
+
+// Object identifiers used by the PKCS#7/CMS SignedData structure we build.
+var (
+	oidData          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData    = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidSigningTime   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidSigningCertV2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 16, 2, 47} // ESS signingCertificateV2 (CAdES)
+	oidSHA256        = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidRSAEncryption = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+)
+
+// SubFilter selects the /SubFilter value for the /Sig dictionary, which in
+// turn determines whether we attach the CAdES ESS signingCertificateV2
+// attribute required for PAdES-B-B conformance.
+type SubFilter string
+
+const (
+	SubFilterAdbePKCS7Detached SubFilter = "adbe.pkcs7.detached"
+	SubFilterETSICAdESDetached SubFilter = "ETSI.CAdES.detached"
+)
+
+// attribute is an RFC 5652 §5.3 Attribute: attrValues is a SET OF
+// AttributeValue, not a bare value, even though every attribute we emit only
+// ever carries one. Value's "set" tag is decorative — asn1.RawValue marshals
+// its FullBytes verbatim regardless of struct tags — so the caller must wrap
+// the value's DER in a SET itself; wrapInSet does that.
+type attribute struct {
+	Type  asn1.ObjectIdentifier
+	Value asn1.RawValue `asn1:"set"`
+}
+
+// wrapInSet wraps an already-DER-encoded value in a SET OF, as RFC 5652 §5.3
+// requires for an Attribute's attrValues.
+func wrapInSet(der []byte) ([]byte, error) {
+	return asn1.Marshal(asn1.RawValue{
+		Class:      asn1.ClassUniversal,
+		Tag:        asn1.TagSet,
+		IsCompound: true,
+		Bytes:      der,
+	})
+}
+
+// wrapExplicit0 wraps an already-DER-encoded value in a [0] EXPLICIT context
+// tag, the same way wrapInSet handles SET OF: the wrapper has to be built by
+// hand because encoding a RawValue ignores the field's own "explicit,tag:0"
+// struct tag.
+func wrapExplicit0(der []byte) ([]byte, error) {
+	return asn1.Marshal(asn1.RawValue{
+		Class:      asn1.ClassContextSpecific,
+		Tag:        0,
+		IsCompound: true,
+		Bytes:      der,
+	})
+}
+
+type issuerAndSerial struct {
+	Issuer asn1.RawValue
+	Serial asn1.RawValue
+}
+
+type signerInfo struct {
+	Version                   int
+	IssuerAndSerialNumber     issuerAndSerial
+	DigestAlgorithm           pkix.AlgorithmIdentifier
+	SignedAttrs               []attribute `asn1:"optional,set,tag:0"`
+	DigestEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedDigest           []byte
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,optional,tag:0"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      contentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type outerContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"explicit,tag:0"`
+}
+
+// BuildSignedData produces a detached CMS SignedData structure (PKCS#7 /
+// CAdES) over messageDigest, the SHA-256 digest of the PDF bytes inside the
+// /ByteRange. The document content itself is never embedded (detached
+// signature), matching /SubFilter adbe.pkcs7.detached or
+// ETSI.CAdES.detached.
+func BuildSignedData(ks *KeyStore, messageDigest []byte, signingTime time.Time, sub SubFilter) ([]byte, error) {
+	digestAlg := pkix.AlgorithmIdentifier{Algorithm: oidSHA256}
+
+	signedAttrs, err := buildSignedAttributes(ks, messageDigest, signingTime, sub)
+	if err != nil {
+		return nil, err
+	}
+
+	attrsForSigning, err := marshalAttributesForSigning(signedAttrs)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.Sum256(attrsForSigning)
+	sig, err := ks.PrivateKey.Sign(rand.Reader, h[:], crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("signature: signing signed attributes: %w", err)
+	}
+
+	serialRaw, err := asn1.Marshal(ks.Certificate.SerialNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	info := signerInfo{
+		Version: 1,
+		IssuerAndSerialNumber: issuerAndSerial{
+			// RawIssuer is already the DER-encoded Name from the parsed
+			// certificate; re-marshaling it would wrap it in an extra OCTET
+			// STRING and produce a Name no validator can match.
+			Issuer: asn1.RawValue{FullBytes: ks.Certificate.RawIssuer},
+			Serial: asn1.RawValue{FullBytes: serialRaw},
+		},
+		DigestAlgorithm:           digestAlg,
+		SignedAttrs:               signedAttrs,
+		DigestEncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidRSAEncryption},
+		EncryptedDigest:           sig,
+	}
+
+	certs := [][]byte{ks.Certificate.Raw}
+	for _, ca := range ks.CAChain {
+		certs = append(certs, ca.Raw)
+	}
+	certsRaw, err := marshalCertificateSet(certs)
+	if err != nil {
+		return nil, err
+	}
+
+	sd := signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{digestAlg},
+		ContentInfo:      contentInfo{ContentType: oidData},
+		Certificates:     asn1.RawValue{FullBytes: certsRaw},
+		SignerInfos:      []signerInfo{info},
+	}
+
+	sdBytes, err := asn1.Marshal(sd)
+	if err != nil {
+		return nil, fmt.Errorf("signature: marshaling SignedData: %w", err)
+	}
+
+	// outerContentInfo.Content is declared [0] EXPLICIT, but like attribute.Value
+	// that struct tag only governs decoding for a RawValue field; encoding it
+	// requires wrapping sdBytes in the context-tag ourselves, via wrapExplicit0.
+	wrappedContent, err := wrapExplicit0(sdBytes)
+	if err != nil {
+		return nil, err
+	}
+	outer := outerContentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{FullBytes: wrappedContent},
+	}
+	return asn1.Marshal(outer)
+}
+
+func buildSignedAttributes(ks *KeyStore, messageDigest []byte, signingTime time.Time, sub SubFilter) ([]attribute, error) {
+	contentTypeVal, err := asn1.Marshal(oidData)
+	if err != nil {
+		return nil, err
+	}
+	digestVal, err := asn1.Marshal(messageDigest)
+	if err != nil {
+		return nil, err
+	}
+	timeVal, err := asn1.MarshalWithParams(signingTime.UTC(), "utc")
+	if err != nil {
+		return nil, err
+	}
+
+	contentTypeSet, err := wrapInSet(contentTypeVal)
+	if err != nil {
+		return nil, err
+	}
+	digestSet, err := wrapInSet(digestVal)
+	if err != nil {
+		return nil, err
+	}
+	timeSet, err := wrapInSet(timeVal)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := []attribute{
+		{Type: oidContentType, Value: asn1.RawValue{FullBytes: contentTypeSet}},
+		{Type: oidMessageDigest, Value: asn1.RawValue{FullBytes: digestSet}},
+		{Type: oidSigningTime, Value: asn1.RawValue{FullBytes: timeSet}},
+	}
+
+	if sub == SubFilterETSICAdESDetached {
+		certHash := sha256.Sum256(ks.Certificate.Raw)
+		essCert, err := buildSigningCertificateV2(certHash[:])
+		if err != nil {
+			return nil, err
+		}
+		essCertSet, err := wrapInSet(essCert)
+		if err != nil {
+			return nil, err
+		}
+		attrs = append(attrs, attribute{Type: oidSigningCertV2, Value: asn1.RawValue{FullBytes: essCertSet}})
+	}
+
+	return attrs, nil
+}
+
+// essCertIDv2 / signingCertificateV2 per RFC 5035, narrowed to the one field
+// (the certificate's SHA-256 hash) every PAdES-B-B validator actually checks.
+type essCertIDv2 struct {
+	HashAlgorithm pkix.AlgorithmIdentifier `asn1:"optional"`
+	CertHash      []byte
+}
+
+type signingCertificateV2 struct {
+	Certs []essCertIDv2
+}
+
+func buildSigningCertificateV2(certHash []byte) ([]byte, error) {
+	sc := signingCertificateV2{
+		Certs: []essCertIDv2{{
+			HashAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidSHA256},
+			CertHash:      certHash,
+		}},
+	}
+	inner, err := asn1.Marshal(sc)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(asn1.RawValue{FullBytes: inner})
+}
+
+// marshalAttributesForSigning re-tags the signed attributes from an
+// implicit [0] SET (as they appear inside SignerInfo) to an explicit SET OF,
+// which is what must actually be hashed and signed per RFC 5652 §5.4.
+func marshalAttributesForSigning(attrs []attribute) ([]byte, error) {
+	return asn1.MarshalWithParams(attrs, "set")
+}
+
+func marshalCertificateSet(certsDER [][]byte) ([]byte, error) {
+	var body bytes.Buffer
+	for _, c := range certsDER {
+		body.Write(c)
+	}
+	// [0] IMPLICIT SET OF Certificate — tag 0xA0, constructed, context class.
+	return asn1.Marshal(asn1.RawValue{
+		Class:      asn1.ClassContextSpecific,
+		Tag:        0,
+		IsCompound: true,
+		Bytes:      body.Bytes(),
+	})
+}
+
+// VerifyCertificateChain checks cert against trustRoots, optionally through
+// intermediates, without requiring online revocation checking (CRL/OCSP is
+// handled separately by the caller since it needs network access).
+func VerifyCertificateChain(cert *x509.Certificate, intermediates, trustRoots []*x509.Certificate) ([][]*x509.Certificate, error) {
+	interPool := x509.NewCertPool()
+	for _, c := range intermediates {
+		interPool.AddCert(c)
+	}
+	rootPool := x509.NewCertPool()
+	for _, c := range trustRoots {
+		rootPool.AddCert(c)
+	}
+	return cert.Verify(x509.VerifyOptions{
+		Intermediates: interPool,
+		Roots:         rootPool,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+}
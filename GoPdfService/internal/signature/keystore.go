@@ -0,0 +1,77 @@
+// Package signature implements detached PAdES/CAdES digital signatures for
+// PDF documents: building a /Sig field with a CMS SignedData blob in
+// /Contents, and verifying one back.
+package signature
+
+import (
+	"crypto"
+	"crypto/x509"
+	"fmt"
+
+	"golang.org/x/crypto/pkcs12"
+)
+
+// KeyStore holds the signing identity loaded from a PKCS#12 (.p12/.pfx)
+// file: the leaf certificate, its private key, and any intermediate CA
+// certificates shipped alongside it for chain building.
+type KeyStore struct {
+	Certificate *x509.Certificate
+	PrivateKey  crypto.Signer
+	CAChain     []*x509.Certificate
+}
+
+// LoadKeyStore decodes a PKCS#12 blob (as uploaded, or read from a
+// configured on-disk path) protected by password. pkcs12.Decode only handles
+// the single-certificate case, so a bundle carrying CA chain certificates
+// alongside the leaf needs ToPEM instead: it returns every safe bag
+// (private key plus all certificates) as PEM blocks, in the order they
+// appear in the PFX, leaf first.
+func LoadKeyStore(p12Data []byte, password string) (*KeyStore, error) {
+	blocks, err := pkcs12.ToPEM(p12Data, password)
+	if err != nil {
+		return nil, fmt.Errorf("signature: decoding PKCS#12: %w", err)
+	}
+
+	var key crypto.Signer
+	var certs []*x509.Certificate
+	for _, block := range blocks {
+		switch block.Type {
+		case "PRIVATE KEY":
+			// ToPEM labels every key "PRIVATE KEY" but encodes RSA keys as
+			// PKCS#1 and EC keys as SEC 1; PKCS#8 is tried last.
+			var k interface{}
+			var err error
+			if k, err = x509.ParsePKCS1PrivateKey(block.Bytes); err != nil {
+				if k, err = x509.ParseECPrivateKey(block.Bytes); err != nil {
+					k, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+				}
+			}
+			if err != nil {
+				return nil, fmt.Errorf("signature: parsing PKCS#12 private key: %w", err)
+			}
+			signer, ok := k.(crypto.Signer)
+			if !ok {
+				return nil, fmt.Errorf("signature: private key type %T does not implement crypto.Signer", k)
+			}
+			key = signer
+		case "CERTIFICATE":
+			cert, err := x509.ParseCertificate(block.Bytes)
+			if err != nil {
+				return nil, fmt.Errorf("signature: parsing PKCS#12 certificate: %w", err)
+			}
+			certs = append(certs, cert)
+		}
+	}
+	if key == nil {
+		return nil, fmt.Errorf("signature: PKCS#12 bundle has no private key")
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("signature: PKCS#12 bundle has no certificate")
+	}
+
+	return &KeyStore{
+		Certificate: certs[0],
+		PrivateKey:  key,
+		CAChain:     certs[1:],
+	}, nil
+}
@@ -0,0 +1,352 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/leszekszpunar/IronPDF-examples/GoPdfService/internal/pdf"
+)
+
+// placeholderContentsBytes reserves room for the CMS blob. 8192 bytes is
+// comfortably larger than any RSA-2048/RSA-4096 detached signature plus its
+// certificate chain; unused trailing bytes stay zero and are ignored by
+// conforming readers since the DER content carries its own length.
+const placeholderContentsBytes = 8192
+
+// byteRangePlaceholder reserves width for each /ByteRange number: 10 digits
+// covers file offsets up to 9,999,999,999 bytes, far past any PDF this
+// service handles, so patchByteRange never has to grow the array after the
+// rest of the file (and the Sig object's own byte offsets) have been fixed.
+const byteRangePlaceholder = 9999999999
+
+// SignOptions configures signature appearance and identity metadata.
+type SignOptions struct {
+	Reason     string
+	Location   string
+	PageNumber int // 1-based; defaults to 1
+	Rect       [4]float64
+	SubFilter  SubFilter
+	Now        time.Time
+}
+
+// Sign appends an incremental update to pdfBytes that adds a /Sig field
+// carrying a detached CMS SignedData signature, without altering a single
+// byte of the original file content.
+func Sign(pdfBytes []byte, ks *KeyStore, opts SignOptions) ([]byte, error) {
+	doc, err := pdf.Open(pdfBytes)
+	if err != nil {
+		return nil, fmt.Errorf("signature: parsing PDF: %w", err)
+	}
+	if opts.SubFilter == "" {
+		opts.SubFilter = SubFilterAdbePKCS7Detached
+	}
+	if opts.PageNumber == 0 {
+		opts.PageNumber = 1
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+
+	rootRef, ok := pdf.AsRef(doc.Trailer["Root"])
+	if !ok {
+		return nil, fmt.Errorf("signature: document trailer has no /Root")
+	}
+	rootObj, err := doc.GetObject(rootRef)
+	if err != nil {
+		return nil, err
+	}
+	rootDict, ok := pdf.AsDict(rootObj.Value)
+	if !ok {
+		return nil, fmt.Errorf("signature: /Root is not a dictionary")
+	}
+
+	pageRef, pageDict, err := nthPage(doc, rootDict, opts.PageNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	maxObjNum := doc.MaxObjectNumber()
+	sigObjNum := maxObjNum + 1
+	widgetObjNum := maxObjNum + 2
+	acroFormObjNum := maxObjNum + 3
+	newCatalogObjNum := rootRef.Num // same number, new revision
+
+	sigPlaceholder := buildSigDict(opts)
+	widgetDict := buildWidgetDict(opts, pageRef, sigObjNum)
+	acroFormDict := pdf.Dict{
+		"Fields":   pdf.Array{pdf.Ref{Num: widgetObjNum, Gen: 0}},
+		"SigFlags": int64(3),
+	}
+
+	newPageDict := pdf.Dict{}
+	for k, v := range pageDict {
+		newPageDict[k] = v
+	}
+	annots, _ := pdf.AsArray(newPageDict["Annots"])
+	newPageDict["Annots"] = append(append(pdf.Array{}, annots...), pdf.Ref{Num: widgetObjNum, Gen: 0})
+
+	newCatalogDict := pdf.Dict{}
+	for k, v := range rootDict {
+		newCatalogDict[k] = v
+	}
+	newCatalogDict["AcroForm"] = pdf.Ref{Num: acroFormObjNum, Gen: 0}
+
+	var appended bytes.Buffer
+	baseOffset := int64(len(pdfBytes))
+	offsets := map[int]int64{}
+
+	contentsOffsetInObj, err := writeSigObject(&appended, sigObjNum, sigPlaceholder)
+	if err != nil {
+		return nil, err
+	}
+	offsets[sigObjNum] = baseOffset
+	contentsFileOffset := baseOffset + int64(contentsOffsetInObj)
+
+	offsets[widgetObjNum] = baseOffset + int64(appended.Len())
+	if err := writeSimpleObject(&appended, widgetObjNum, widgetDict); err != nil {
+		return nil, err
+	}
+	offsets[acroFormObjNum] = baseOffset + int64(appended.Len())
+	if err := writeSimpleObject(&appended, acroFormObjNum, acroFormDict); err != nil {
+		return nil, err
+	}
+	offsets[pageRef.Num] = baseOffset + int64(appended.Len())
+	if err := writeSimpleObject(&appended, pageRef.Num, newPageDict); err != nil {
+		return nil, err
+	}
+	offsets[newCatalogObjNum] = baseOffset + int64(appended.Len())
+	if err := writeSimpleObject(&appended, newCatalogObjNum, newCatalogDict); err != nil {
+		return nil, err
+	}
+
+	prevOffset, _ := startXRefOffset(pdfBytes)
+	trailer := pdf.Dict{
+		"Root": pdf.Ref{Num: newCatalogObjNum, Gen: 0},
+		"Prev": prevOffset,
+	}
+	if err := pdf.WriteIncrementalXRefTable(&appended, offsets, maxObjNum, trailer, baseOffset); err != nil {
+		return nil, err
+	}
+
+	full := append(append([]byte{}, pdfBytes...), appended.Bytes()...)
+
+	ltIdx, gtIdx, err := findContentsHexRange(full, contentsFileOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	byteRange := [4]int64{0, ltIdx + 1, gtIdx, int64(len(full)) - gtIdx}
+	if err := patchByteRange(full, contentsFileOffset, byteRange); err != nil {
+		return nil, err
+	}
+
+	digest := sha256.New()
+	digest.Write(full[byteRange[0] : byteRange[0]+byteRange[1]])
+	digest.Write(full[byteRange[2] : byteRange[2]+byteRange[3]])
+
+	cms, err := BuildSignedData(ks, digest.Sum(nil), opts.Now, opts.SubFilter)
+	if err != nil {
+		return nil, err
+	}
+	if len(cms) > placeholderContentsBytes {
+		return nil, fmt.Errorf("signature: CMS blob (%d bytes) exceeds reserved placeholder (%d bytes)", len(cms), placeholderContentsBytes)
+	}
+
+	hexSig := make([]byte, placeholderContentsBytes*2)
+	copy(hexSig, []byte(hex.EncodeToString(cms)))
+	for i := len(hex.EncodeToString(cms)); i < len(hexSig); i++ {
+		hexSig[i] = '0'
+	}
+	copy(full[ltIdx+1:gtIdx], hexSig)
+
+	return full, nil
+}
+
+func nthPage(doc *pdf.Document, rootDict pdf.Dict, pageNumber int) (pdf.Ref, pdf.Dict, error) {
+	pagesRef, ok := pdf.AsRef(rootDict["Pages"])
+	if !ok {
+		return pdf.Ref{}, nil, fmt.Errorf("signature: /Root has no /Pages")
+	}
+	var pages []pdf.Ref
+	var walk func(ref pdf.Ref) error
+	walk = func(ref pdf.Ref) error {
+		obj, err := doc.GetObject(ref)
+		if err != nil {
+			return err
+		}
+		dict, ok := pdf.AsDict(obj.Value)
+		if !ok {
+			return fmt.Errorf("signature: page tree node %d is not a dictionary", ref.Num)
+		}
+		if t, _ := pdf.AsName(dict["Type"]); t == "Page" {
+			pages = append(pages, ref)
+			return nil
+		}
+		kids, _ := pdf.AsArray(dict["Kids"])
+		for _, kid := range kids {
+			if kidRef, ok := pdf.AsRef(kid); ok {
+				if err := walk(kidRef); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	if err := walk(pagesRef); err != nil {
+		return pdf.Ref{}, nil, err
+	}
+	if pageNumber < 1 || pageNumber > len(pages) {
+		return pdf.Ref{}, nil, fmt.Errorf("signature: page %d out of range (document has %d pages)", pageNumber, len(pages))
+	}
+	ref := pages[pageNumber-1]
+	obj, err := doc.GetObject(ref)
+	if err != nil {
+		return pdf.Ref{}, nil, err
+	}
+	dict, _ := pdf.AsDict(obj.Value)
+	return ref, dict, nil
+}
+
+func buildSigDict(opts SignOptions) pdf.Dict {
+	d := pdf.Dict{
+		"Type":      pdf.Name("Sig"),
+		"Filter":    pdf.Name("Adobe.PPKLite"),
+		"SubFilter": pdf.Name(string(opts.SubFilter)),
+		"ByteRange": pdf.Array{int64(byteRangePlaceholder), int64(byteRangePlaceholder), int64(byteRangePlaceholder), int64(byteRangePlaceholder)},
+		"Contents":  pdf.HexString(zeroHex(placeholderContentsBytes)),
+		"M":         pdfDate(opts.Now),
+	}
+	if opts.Reason != "" {
+		d["Reason"] = opts.Reason
+	}
+	if opts.Location != "" {
+		d["Location"] = opts.Location
+	}
+	return d
+}
+
+func buildWidgetDict(opts SignOptions, pageRef pdf.Ref, sigObjNum int) pdf.Dict {
+	rect := pdf.Array{}
+	for _, v := range opts.Rect {
+		rect = append(rect, v)
+	}
+	if len(rect) == 0 {
+		rect = pdf.Array{float64(0), float64(0), float64(0), float64(0)}
+	}
+	return pdf.Dict{
+		"Type":    pdf.Name("Annot"),
+		"Subtype": pdf.Name("Widget"),
+		"FT":      pdf.Name("Sig"),
+		"Rect":    rect,
+		"P":       pageRef,
+		"V":       pdf.Ref{Num: sigObjNum, Gen: 0},
+		"T":       "Signature1",
+		"F":       int64(132), // Print | Locked
+	}
+}
+
+func zeroHex(n int) string {
+	b := make([]byte, n*2)
+	for i := range b {
+		b[i] = '0'
+	}
+	return string(b)
+}
+
+func pdfDate(t time.Time) string {
+	return "D:" + t.Format("20060102150405")
+}
+
+// writeSigObject writes the Sig object and returns the byte offset, relative
+// to the start of the object's serialized bytes, just past the "/Contents"
+// key — findContentsHexRange scans forward from there for the hex string's
+// opening '<', since the generic dict writer may or may not put a space
+// before it.
+func writeSigObject(w *bytes.Buffer, num int, dict pdf.Dict) (int, error) {
+	before := w.Len()
+	if err := writeSimpleObject(w, num, dict); err != nil {
+		return 0, err
+	}
+	chunk := w.Bytes()[before:]
+	idx := bytes.Index(chunk, []byte("/Contents"))
+	if idx == -1 {
+		return 0, fmt.Errorf("signature: could not locate /Contents placeholder")
+	}
+	return idx + len("/Contents"), nil
+}
+
+func writeSimpleObject(w *bytes.Buffer, num int, value interface{}) error {
+	obj := &pdf.Object{Ref: pdf.Ref{Num: num, Gen: 0}, Value: value}
+	return pdf.WriteIndirectObject(w, obj)
+}
+
+var startxrefRe = regexp.MustCompile(`startxref\s+(\d+)`)
+
+func startXRefOffset(buf []byte) (int64, error) {
+	tail := buf
+	if len(tail) > 2048 {
+		tail = tail[len(tail)-2048:]
+	}
+	m := startxrefRe.FindSubmatch(tail)
+	if m == nil {
+		return 0, fmt.Errorf("signature: startxref not found")
+	}
+	return strconv.ParseInt(string(m[1]), 10, 64)
+}
+
+// findContentsHexRange scans forward from approxOffset (just past the
+// "/Contents" key, as writeSigObject reports it) for the '<' and matching '>' of
+// the hex string value, since exact spacing can shift by a byte depending on
+// how the generic dict writer orders neighbouring keys.
+func findContentsHexRange(full []byte, approxOffset int64) (int64, int64, error) {
+	window := full[approxOffset:]
+	ltRel := bytes.IndexByte(window, '<')
+	if ltRel == -1 {
+		return 0, 0, fmt.Errorf("signature: could not find Contents '<'")
+	}
+	gtRel := bytes.IndexByte(window[ltRel:], '>')
+	if gtRel == -1 {
+		return 0, 0, fmt.Errorf("signature: could not find Contents '>'")
+	}
+	lt := approxOffset + int64(ltRel)
+	gt := lt + int64(gtRel)
+	return lt, gt, nil
+}
+
+func patchByteRange(full []byte, contentsFileOffset int64, byteRange [4]int64) error {
+	// The Sig dictionary's /ByteRange array was written as the placeholder
+	// reserved by buildSigDict; locate it the same way we located /Contents,
+	// scanning backwards from contentsFileOffset since /ByteRange sorts
+	// before /Contents alphabetically in our writer's key ordering. The
+	// generic dict writer may or may not put a space before the '[', so find
+	// the key first and then scan forward for it.
+	window := full[:contentsFileOffset]
+	idx := bytes.LastIndex(window, []byte("/ByteRange"))
+	if idx == -1 {
+		return fmt.Errorf("signature: could not locate /ByteRange placeholder")
+	}
+	afterKey := idx + len("/ByteRange")
+	openRel := bytes.IndexByte(full[afterKey:contentsFileOffset], '[')
+	if openRel == -1 {
+		return fmt.Errorf("signature: could not find /ByteRange '['")
+	}
+	start := afterKey + openRel + 1
+	end := bytes.IndexByte(full[start:], ']')
+	if end == -1 {
+		return fmt.Errorf("signature: could not find /ByteRange ']'")
+	}
+	replacement := fmt.Sprintf("%d %d %d %d", byteRange[0], byteRange[1], byteRange[2], byteRange[3])
+	if len(replacement) > end {
+		return fmt.Errorf("signature: /ByteRange placeholder (%d bytes) is too narrow for %q", end, replacement)
+	}
+	for len(replacement) < end {
+		replacement += " "
+	}
+	copy(full[start:start+end], []byte(replacement))
+	return nil
+}
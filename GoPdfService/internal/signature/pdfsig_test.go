@@ -0,0 +1,63 @@
+package signature
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteSigObjectLocatesContentsPastKeySpace(t *testing.T) {
+	var w bytes.Buffer
+	dict := buildSigDict(SignOptions{})
+
+	offset, err := writeSigObject(&w, 1, dict)
+	if err != nil {
+		t.Fatalf("writeSigObject: %v", err)
+	}
+
+	full := w.Bytes()
+	lt, gt, err := findContentsHexRange(full, int64(offset))
+	if err != nil {
+		t.Fatalf("findContentsHexRange: %v", err)
+	}
+	if full[lt] != '<' || full[gt] != '>' {
+		t.Fatalf("expected '<' at %d and '>' at %d, got %q and %q", lt, gt, full[lt], full[gt])
+	}
+}
+
+func TestPatchByteRangeFindsBracketPastKeySpace(t *testing.T) {
+	var w bytes.Buffer
+	dict := buildSigDict(SignOptions{})
+
+	offset, err := writeSigObject(&w, 1, dict)
+	if err != nil {
+		t.Fatalf("writeSigObject: %v", err)
+	}
+
+	full := w.Bytes()
+	contentsOffset, _, err := findContentsHexRange(full, int64(offset))
+	if err != nil {
+		t.Fatalf("findContentsHexRange: %v", err)
+	}
+
+	if err := patchByteRange(full, contentsOffset, [4]int64{0, 10, 20, 30}); err != nil {
+		t.Fatalf("patchByteRange: %v", err)
+	}
+
+	idx := bytes.Index(full, []byte("/ByteRange"))
+	if idx == -1 {
+		t.Fatalf("/ByteRange not found after patch")
+	}
+	open := bytes.IndexByte(full[idx:], '[')
+	if open == -1 {
+		t.Fatalf("/ByteRange '[' not found after patch")
+	}
+	closeIdx := bytes.IndexByte(full[idx+open:], ']')
+	if closeIdx == -1 {
+		t.Fatalf("/ByteRange ']' not found after patch")
+	}
+	patched := string(full[idx+open+1 : idx+open+closeIdx])
+	want := "0 10 20 30"
+	if len(patched) < len(want) || patched[:len(want)] != want {
+		t.Fatalf("patched /ByteRange = %q, want prefix %q", patched, want)
+	}
+}
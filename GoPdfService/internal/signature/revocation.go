@@ -0,0 +1,99 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+)
+
+// RevocationStatus is the outcome of an optional CRL/OCSP check against a
+// signer certificate. Checking revocation requires network access, so unlike
+// chain verification it's only attempted when the caller actually supplies
+// an issuer (needed to build the OCSP request) and opts into it.
+type RevocationStatus struct {
+	Checked bool
+	Revoked bool
+	Source  string // "ocsp" or "crl"
+	Error   string
+}
+
+// CheckOCSP queries the OCSP responder named in cert's AuthorityInfoAccess
+// (or responderURL, if provided) and reports whether cert has been revoked.
+func CheckOCSP(cert, issuer *x509.Certificate, responderURL string) RevocationStatus {
+	url := responderURL
+	if url == "" {
+		if len(cert.OCSPServer) == 0 {
+			return RevocationStatus{Checked: false}
+		}
+		url = cert.OCSPServer[0]
+	}
+
+	req, err := ocsp.CreateRequest(cert, issuer, nil)
+	if err != nil {
+		return RevocationStatus{Checked: true, Source: "ocsp", Error: fmt.Sprintf("building OCSP request: %v", err)}
+	}
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	resp, err := httpClient.Post(url, "application/ocsp-request", bytes.NewReader(req))
+	if err != nil {
+		return RevocationStatus{Checked: true, Source: "ocsp", Error: fmt.Sprintf("OCSP request failed: %v", err)}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return RevocationStatus{Checked: true, Source: "ocsp", Error: fmt.Sprintf("reading OCSP response: %v", err)}
+	}
+
+	parsed, err := ocsp.ParseResponseForCert(body, cert, issuer)
+	if err != nil {
+		return RevocationStatus{Checked: true, Source: "ocsp", Error: fmt.Sprintf("parsing OCSP response: %v", err)}
+	}
+
+	return RevocationStatus{
+		Checked: true,
+		Revoked: parsed.Status == ocsp.Revoked,
+		Source:  "ocsp",
+	}
+}
+
+// CheckCRL downloads the CRL named in cert's CRLDistributionPoints (or
+// crlURL, if provided) and checks whether cert's serial number is listed.
+func CheckCRL(cert *x509.Certificate, crlURL string) RevocationStatus {
+	url := crlURL
+	if url == "" {
+		if len(cert.CRLDistributionPoints) == 0 {
+			return RevocationStatus{Checked: false}
+		}
+		url = cert.CRLDistributionPoints[0]
+	}
+
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	resp, err := httpClient.Get(url)
+	if err != nil {
+		return RevocationStatus{Checked: true, Source: "crl", Error: fmt.Sprintf("downloading CRL: %v", err)}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return RevocationStatus{Checked: true, Source: "crl", Error: fmt.Sprintf("reading CRL: %v", err)}
+	}
+
+	crl, err := x509.ParseRevocationList(body)
+	if err != nil {
+		return RevocationStatus{Checked: true, Source: "crl", Error: fmt.Sprintf("parsing CRL: %v", err)}
+	}
+
+	for _, entry := range crl.RevokedCertificateEntries {
+		if entry.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+			return RevocationStatus{Checked: true, Source: "crl", Revoked: true}
+		}
+	}
+	return RevocationStatus{Checked: true, Source: "crl", Revoked: false}
+}
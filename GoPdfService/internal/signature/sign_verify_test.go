@@ -0,0 +1,91 @@
+package signature
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/leszekszpunar/IronPDF-examples/GoPdfService/internal/pdf"
+)
+
+// selfSignedKeyStore builds a KeyStore around a throwaway self-signed RSA
+// certificate, bypassing PKCS#12 parsing entirely so the test exercises
+// Sign/Verify's CMS handling in isolation.
+func selfSignedKeyStore(t *testing.T) *KeyStore {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "test signer"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("CreateCertificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate: %v", err)
+	}
+
+	return &KeyStore{Certificate: cert, PrivateKey: key}
+}
+
+func onePagePDF(t *testing.T) []byte {
+	t.Helper()
+	objects := map[int]*pdf.Object{
+		1: {Ref: pdf.Ref{Num: 1, Gen: 0}, Value: pdf.Dict{
+			"Type":  pdf.Name("Catalog"),
+			"Pages": pdf.Ref{Num: 2, Gen: 0},
+		}},
+		2: {Ref: pdf.Ref{Num: 2, Gen: 0}, Value: pdf.Dict{
+			"Type":  pdf.Name("Pages"),
+			"Kids":  pdf.Array{pdf.Ref{Num: 3, Gen: 0}},
+			"Count": int64(1),
+		}},
+		3: {Ref: pdf.Ref{Num: 3, Gen: 0}, Value: pdf.Dict{
+			"Type":      pdf.Name("Page"),
+			"Parent":    pdf.Ref{Num: 2, Gen: 0},
+			"MediaBox":  pdf.Array{int64(0), int64(0), int64(612), int64(792)},
+			"Resources": pdf.Dict{},
+		}},
+	}
+	var buf bytes.Buffer
+	if err := pdf.Write(&buf, objects, pdf.Dict{"Root": pdf.Ref{Num: 1, Gen: 0}}, pdf.WriteOptions{}); err != nil {
+		t.Fatalf("pdf.Write: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestSignThenVerifyRoundTrip(t *testing.T) {
+	ks := selfSignedKeyStore(t)
+	src := onePagePDF(t)
+
+	signed, err := Sign(src, ks, SignOptions{Reason: "testing", Now: time.Now()})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+
+	result, err := Verify(signed, nil)
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if len(result.Errors) != 0 {
+		t.Fatalf("Verify reported errors: %v", result.Errors)
+	}
+	if !result.IntegrityIntact {
+		t.Fatalf("IntegrityIntact = false, want true")
+	}
+}
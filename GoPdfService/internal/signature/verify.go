@@ -0,0 +1,251 @@
+package signature
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/leszekszpunar/IronPDF-examples/GoPdfService/internal/pdf"
+)
+
+// VerifyResult summarizes the outcome of validating a PDF's /Sig field.
+type VerifyResult struct {
+	IntegrityIntact bool
+	ChainTrusted    bool
+	SignerDN        string
+	SigningTime     time.Time
+	Reason          string
+	Location        string
+	SubFilter       string
+	Errors          []string
+}
+
+// Verify locates the first /Sig field in pdfBytes, re-hashes the bytes its
+// /ByteRange covers, parses the embedded CMS SignedData, and checks both the
+// CMS signature and (when trustRoots is non-empty) the certificate chain.
+func Verify(pdfBytes []byte, trustRoots []*x509.Certificate) (*VerifyResult, error) {
+	doc, err := pdf.Open(pdfBytes)
+	if err != nil {
+		return nil, fmt.Errorf("signature: parsing PDF: %w", err)
+	}
+
+	sigDict, err := findSignatureDict(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &VerifyResult{}
+	if reason, ok := sigDict["Reason"].(string); ok {
+		result.Reason = reason
+	}
+	if location, ok := sigDict["Location"].(string); ok {
+		result.Location = location
+	}
+	if sub, ok := pdf.AsName(sigDict["SubFilter"]); ok {
+		result.SubFilter = string(sub)
+	}
+
+	byteRange, err := readByteRange(sigDict)
+	if err != nil {
+		return nil, err
+	}
+	cmsBytes, err := readContentsBytes(sigDict)
+	if err != nil {
+		return nil, err
+	}
+
+	h := sha256.New()
+	h.Write(pdfBytes[byteRange[0] : byteRange[0]+byteRange[1]])
+	h.Write(pdfBytes[byteRange[2] : byteRange[2]+byteRange[3]])
+	actualDigest := h.Sum(nil)
+
+	var outer outerContentInfo
+	if _, err := asn1.Unmarshal(cmsBytes, &outer); err != nil {
+		return nil, fmt.Errorf("signature: parsing CMS ContentInfo: %w", err)
+	}
+	var sd signedData
+	if _, err := asn1.Unmarshal(outer.Content.Bytes, &sd); err != nil {
+		return nil, fmt.Errorf("signature: parsing CMS SignedData: %w", err)
+	}
+	if len(sd.SignerInfos) == 0 {
+		return nil, fmt.Errorf("signature: CMS SignedData has no SignerInfo")
+	}
+	signer := sd.SignerInfos[0]
+
+	certs, err := x509.ParseCertificates(sd.Certificates.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("signature: parsing embedded certificates: %w", err)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("signature: CMS SignedData carries no certificates")
+	}
+	signerCert := certs[0]
+	intermediates := certs[1:]
+
+	result.SignerDN = signerCert.Subject.String()
+
+	messageDigest, signingTime, err := readSignedAttrs(signer.SignedAttrs)
+	if err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	} else {
+		result.SigningTime = signingTime
+		result.IntegrityIntact = bytes.Equal(messageDigest, actualDigest)
+		if !result.IntegrityIntact {
+			result.Errors = append(result.Errors, "document content does not match the signed message digest")
+		}
+	}
+
+	attrsForSigning, err := marshalAttributesForSigning(signer.SignedAttrs)
+	if err != nil {
+		return nil, err
+	}
+	attrsHash := sha256.Sum256(attrsForSigning)
+
+	rsaPub, ok := signerCert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		result.Errors = append(result.Errors, fmt.Sprintf("unsupported signer public key type %T", signerCert.PublicKey))
+	} else if err := rsa.VerifyPKCS1v15(rsaPub, crypto.SHA256, attrsHash[:], signer.EncryptedDigest); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("signature verification failed: %v", err))
+	}
+
+	if len(trustRoots) > 0 {
+		if _, err := VerifyCertificateChain(signerCert, intermediates, trustRoots); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("certificate chain not trusted: %v", err))
+		} else {
+			result.ChainTrusted = true
+		}
+	}
+
+	return result, nil
+}
+
+func findSignatureDict(doc *pdf.Document) (pdf.Dict, error) {
+	rootRef, ok := pdf.AsRef(doc.Trailer["Root"])
+	if !ok {
+		return nil, fmt.Errorf("signature: document trailer has no /Root")
+	}
+	rootObj, err := doc.GetObject(rootRef)
+	if err != nil {
+		return nil, err
+	}
+	rootDict, ok := pdf.AsDict(rootObj.Value)
+	if !ok {
+		return nil, fmt.Errorf("signature: /Root is not a dictionary")
+	}
+	acroFormRef, ok := pdf.AsRef(rootDict["AcroForm"])
+	if !ok {
+		return nil, fmt.Errorf("signature: document has no /AcroForm, so no signature field")
+	}
+	acroFormObj, err := doc.GetObject(acroFormRef)
+	if err != nil {
+		return nil, err
+	}
+	acroFormDict, ok := pdf.AsDict(acroFormObj.Value)
+	if !ok {
+		return nil, fmt.Errorf("signature: /AcroForm is not a dictionary")
+	}
+	fields, _ := pdf.AsArray(acroFormDict["Fields"])
+	for _, f := range fields {
+		fieldRef, ok := pdf.AsRef(f)
+		if !ok {
+			continue
+		}
+		fieldObj, err := doc.GetObject(fieldRef)
+		if err != nil {
+			continue
+		}
+		fieldDict, ok := pdf.AsDict(fieldObj.Value)
+		if !ok {
+			continue
+		}
+		if ft, _ := pdf.AsName(fieldDict["FT"]); ft != "Sig" {
+			continue
+		}
+		sigRef, ok := pdf.AsRef(fieldDict["V"])
+		if !ok {
+			continue
+		}
+		sigObj, err := doc.GetObject(sigRef)
+		if err != nil {
+			continue
+		}
+		if sigDict, ok := pdf.AsDict(sigObj.Value); ok {
+			return sigDict, nil
+		}
+	}
+	return nil, fmt.Errorf("signature: no signature field found in /AcroForm")
+}
+
+func readByteRange(sigDict pdf.Dict) ([4]int64, error) {
+	arr, ok := pdf.AsArray(sigDict["ByteRange"])
+	if !ok || len(arr) != 4 {
+		return [4]int64{}, fmt.Errorf("signature: /Sig missing valid /ByteRange")
+	}
+	var out [4]int64
+	for i, v := range arr {
+		switch n := v.(type) {
+		case int64:
+			out[i] = n
+		case float64:
+			out[i] = int64(n)
+		default:
+			return [4]int64{}, fmt.Errorf("signature: /ByteRange entry %d is not a number", i)
+		}
+	}
+	return out, nil
+}
+
+func readContentsBytes(sigDict pdf.Dict) ([]byte, error) {
+	switch v := sigDict["Contents"].(type) {
+	case pdf.HexString:
+		decoded, err := hex.DecodeString(trimTrailingZeroPadding(string(v)))
+		if err != nil {
+			return nil, fmt.Errorf("signature: /Contents is not valid hex: %w", err)
+		}
+		return decoded, nil
+	case string:
+		return []byte(v), nil
+	default:
+		return nil, fmt.Errorf("signature: /Sig missing /Contents")
+	}
+}
+
+// trimTrailingZeroPadding strips the "00" hex pairs Sign() pads after the
+// real CMS DER content, so only the DER itself is hex-decoded.
+func trimTrailingZeroPadding(hexStr string) string {
+	end := len(hexStr)
+	for end >= 2 && hexStr[end-2:end] == "00" {
+		end -= 2
+	}
+	return hexStr[:end]
+}
+
+func readSignedAttrs(attrs []attribute) (messageDigest []byte, signingTime time.Time, err error) {
+	for _, a := range attrs {
+		// a.Value is the attribute's attrValues SET OF AttributeValue (RFC
+		// 5652 §5.3); since we only ever emit one value per attribute,
+		// a.Value.Bytes (the SET's content) is exactly the inner value's own
+		// full DER encoding. a.Value.FullBytes is the outer SET's tag+length
+		// instead and won't match the OCTET STRING/UTCTime tag being parsed.
+		switch {
+		case a.Type.Equal(oidMessageDigest):
+			if _, err := asn1.Unmarshal(a.Value.Bytes, &messageDigest); err != nil {
+				return nil, time.Time{}, fmt.Errorf("signature: parsing messageDigest attribute: %w", err)
+			}
+		case a.Type.Equal(oidSigningTime):
+			if _, err := asn1.UnmarshalWithParams(a.Value.Bytes, &signingTime, "utc"); err != nil {
+				return nil, time.Time{}, fmt.Errorf("signature: parsing signingTime attribute: %w", err)
+			}
+		}
+	}
+	if messageDigest == nil {
+		return nil, time.Time{}, fmt.Errorf("signature: CMS signed attributes missing messageDigest")
+	}
+	return messageDigest, signingTime, nil
+}
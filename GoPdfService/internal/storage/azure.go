@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
+)
+
+// AzureBackend stores artifacts as blobs in an Azure Storage container.
+type AzureBackend struct {
+	client    *azblob.Client
+	container string
+	prefix    string
+}
+
+// AzureConfig carries the env-derived settings for NewAzureBackend.
+type AzureConfig struct {
+	AccountName   string
+	AccountKey    string
+	ContainerName string
+	Prefix        string
+}
+
+// NewAzureBackend builds an AzureBackend authenticated with a shared
+// storage-account key.
+func NewAzureBackend(cfg AzureConfig) (*AzureBackend, error) {
+	cred, err := azblob.NewSharedKeyCredential(cfg.AccountName, cfg.AccountKey)
+	if err != nil {
+		return nil, fmt.Errorf("storage: building Azure shared key credential: %w", err)
+	}
+
+	serviceURL := fmt.Sprintf("https://%s.blob.core.windows.net/", cfg.AccountName)
+	client, err := azblob.NewClientWithSharedKeyCredential(serviceURL, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("storage: creating Azure client: %w", err)
+	}
+
+	return &AzureBackend{client: client, container: cfg.ContainerName, prefix: cfg.Prefix}, nil
+}
+
+func (b *AzureBackend) blobName(key string) string {
+	if b.prefix == "" {
+		return key
+	}
+	return b.prefix + "/" + key
+}
+
+func (b *AzureBackend) Put(ctx context.Context, key string, r io.Reader, meta Metadata) (string, error) {
+	_, err := b.client.UploadStream(ctx, b.container, b.blobName(key), r, &azblob.UploadStreamOptions{
+		HTTPHeaders: &blob.HTTPHeaders{
+			BlobContentType:        &meta.ContentType,
+			BlobContentDisposition: stringPtr(fmt.Sprintf("attachment; filename=%s", meta.Filename)),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("storage: uploading %s to Azure Blob: %w", key, err)
+	}
+	return b.SignedURL(ctx, key, 15*time.Minute)
+}
+
+func (b *AzureBackend) Get(ctx context.Context, key string) (io.ReadCloser, Metadata, error) {
+	resp, err := b.client.DownloadStream(ctx, b.container, b.blobName(key), nil)
+	if err != nil {
+		return nil, Metadata{}, fmt.Errorf("storage: fetching %s from Azure Blob: %w", key, err)
+	}
+	meta := Metadata{}
+	if resp.ContentType != nil {
+		meta.ContentType = *resp.ContentType
+	}
+	return resp.Body, meta, nil
+}
+
+func (b *AzureBackend) Delete(ctx context.Context, key string) error {
+	_, err := b.client.DeleteBlob(ctx, b.container, b.blobName(key), nil)
+	if err != nil && !bloberror.HasCode(err, bloberror.BlobNotFound) {
+		return fmt.Errorf("storage: deleting %s from Azure Blob: %w", key, err)
+	}
+	return nil
+}
+
+func (b *AzureBackend) SignedURL(_ context.Context, key string, expiry time.Duration) (string, error) {
+	udc, err := b.client.ServiceClient().GetUserDelegationCredential(context.Background(), service.KeyInfo{
+		Expiry: stringPtr(time.Now().Add(expiry).UTC().Format(time.RFC3339)),
+	}, nil)
+	if err != nil {
+		return "", fmt.Errorf("storage: requesting Azure user delegation key: %w", err)
+	}
+
+	sasQuery, err := sas.BlobSignatureValues{
+		Protocol:      sas.ProtocolHTTPS,
+		ExpiryTime:    time.Now().UTC().Add(expiry),
+		ContainerName: b.container,
+		BlobName:      b.blobName(key),
+		Permissions:   (&sas.BlobPermissions{Read: true}).String(),
+	}.SignWithUserDelegation(udc)
+	if err != nil {
+		return "", fmt.Errorf("storage: signing Azure SAS for %s: %w", key, err)
+	}
+
+	return fmt.Sprintf("%s?%s", b.client.ServiceClient().NewContainerClient(b.container).NewBlobClient(b.blobName(key)).URL(), sasQuery.Encode()), nil
+}
+
+func stringPtr(s string) *string { return &s }
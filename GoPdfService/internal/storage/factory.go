@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// NewFromEnv selects and constructs a Backend based on the STORAGE_DRIVER
+// environment variable, mirroring how Terraform registers remote-state
+// backends by name. Recognised drivers: "local" (default), "s3", "azure",
+// "gcs", "oss".
+func NewFromEnv(ctx context.Context) (Backend, error) {
+	switch strings.ToLower(os.Getenv("STORAGE_DRIVER")) {
+	case "", "local":
+		dir := os.Getenv("STORAGE_LOCAL_DIR")
+		if dir == "" {
+			dir = "./storage-artifacts"
+		}
+		baseURL := os.Getenv("STORAGE_PUBLIC_BASE_URL")
+		if baseURL == "" {
+			baseURL = "/api/pdf/artifacts"
+		}
+		return NewLocalBackend(dir, baseURL)
+
+	case "s3":
+		return NewS3Backend(ctx, S3Config{
+			Bucket:          os.Getenv("STORAGE_BUCKET"),
+			Region:          os.Getenv("STORAGE_REGION"),
+			Endpoint:        os.Getenv("STORAGE_ENDPOINT"),
+			AccessKeyID:     os.Getenv("STORAGE_ACCESS_KEY"),
+			SecretAccessKey: os.Getenv("STORAGE_SECRET_KEY"),
+			Prefix:          os.Getenv("STORAGE_PREFIX"),
+			ForcePathStyle:  os.Getenv("STORAGE_FORCE_PATH_STYLE") == "true",
+		})
+
+	case "azure":
+		return NewAzureBackend(AzureConfig{
+			AccountName:   os.Getenv("STORAGE_ACCOUNT_NAME"),
+			AccountKey:    os.Getenv("STORAGE_ACCESS_KEY"),
+			ContainerName: os.Getenv("STORAGE_BUCKET"),
+			Prefix:        os.Getenv("STORAGE_PREFIX"),
+		})
+
+	case "gcs":
+		return NewGCSBackend(ctx, GCSConfig{
+			Bucket:              os.Getenv("STORAGE_BUCKET"),
+			Prefix:              os.Getenv("STORAGE_PREFIX"),
+			CredentialsFile:     os.Getenv("STORAGE_CREDENTIALS_FILE"),
+			ServiceAccountEmail: os.Getenv("STORAGE_SERVICE_ACCOUNT_EMAIL"),
+		})
+
+	case "oss":
+		return NewOSSBackend(OSSConfig{
+			Endpoint:        os.Getenv("STORAGE_ENDPOINT"),
+			AccessKeyID:     os.Getenv("STORAGE_ACCESS_KEY"),
+			AccessKeySecret: os.Getenv("STORAGE_SECRET_KEY"),
+			BucketName:      os.Getenv("STORAGE_BUCKET"),
+			Prefix:          os.Getenv("STORAGE_PREFIX"),
+		})
+
+	default:
+		return nil, fmt.Errorf("storage: unknown STORAGE_DRIVER %q", os.Getenv("STORAGE_DRIVER"))
+	}
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"google.golang.org/api/option"
+)
+
+// GCSBackend stores artifacts as objects in a Google Cloud Storage bucket.
+type GCSBackend struct {
+	client  *storage.Client
+	bucket  string
+	prefix  string
+	saEmail string // service account email, required to sign URLs
+}
+
+// GCSConfig carries the env-derived settings for NewGCSBackend.
+type GCSConfig struct {
+	Bucket              string
+	Prefix              string
+	CredentialsFile     string // path to a service-account JSON key
+	ServiceAccountEmail string
+}
+
+// NewGCSBackend builds a GCSBackend authenticated with a service-account
+// JSON key file.
+func NewGCSBackend(ctx context.Context, cfg GCSConfig) (*GCSBackend, error) {
+	client, err := storage.NewClient(ctx, option.WithCredentialsFile(cfg.CredentialsFile))
+	if err != nil {
+		return nil, fmt.Errorf("storage: creating GCS client: %w", err)
+	}
+	return &GCSBackend{client: client, bucket: cfg.Bucket, prefix: cfg.Prefix, saEmail: cfg.ServiceAccountEmail}, nil
+}
+
+func (b *GCSBackend) objectName(key string) string {
+	if b.prefix == "" {
+		return key
+	}
+	return b.prefix + "/" + key
+}
+
+func (b *GCSBackend) Put(ctx context.Context, key string, r io.Reader, meta Metadata) (string, error) {
+	obj := b.client.Bucket(b.bucket).Object(b.objectName(key))
+	w := obj.NewWriter(ctx)
+	w.ContentType = meta.ContentType
+	w.ContentDisposition = fmt.Sprintf("attachment; filename=%s", meta.Filename)
+
+	if _, err := io.Copy(w, r); err != nil {
+		w.Close()
+		return "", fmt.Errorf("storage: uploading %s to GCS: %w", key, err)
+	}
+	if err := w.Close(); err != nil {
+		return "", fmt.Errorf("storage: finalizing %s on GCS: %w", key, err)
+	}
+
+	return b.SignedURL(ctx, key, 15*time.Minute)
+}
+
+func (b *GCSBackend) Get(ctx context.Context, key string) (io.ReadCloser, Metadata, error) {
+	r, err := b.client.Bucket(b.bucket).Object(b.objectName(key)).NewReader(ctx)
+	if err != nil {
+		return nil, Metadata{}, fmt.Errorf("storage: fetching %s from GCS: %w", key, err)
+	}
+	return r, Metadata{ContentType: r.Attrs.ContentType}, nil
+}
+
+func (b *GCSBackend) Delete(ctx context.Context, key string) error {
+	err := b.client.Bucket(b.bucket).Object(b.objectName(key)).Delete(ctx)
+	if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+		return fmt.Errorf("storage: deleting %s from GCS: %w", key, err)
+	}
+	return nil
+}
+
+func (b *GCSBackend) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	url, err := b.client.Bucket(b.bucket).SignedURL(b.objectName(key), &storage.SignedURLOptions{
+		GoogleAccessID: b.saEmail,
+		Method:         "GET",
+		Expires:        time.Now().Add(expiry),
+	})
+	if err != nil {
+		return "", fmt.Errorf("storage: signing GCS URL for %s: %w", key, err)
+	}
+	return url, nil
+}
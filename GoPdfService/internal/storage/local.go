@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LocalBackend stores artifacts as plain files on disk, with a JSON sidecar
+// file carrying the Metadata. It is the default backend and requires no
+// credentials, which keeps local development working out of the box.
+type LocalBackend struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalBackend roots a LocalBackend at dir (created if missing). baseURL
+// is prefixed to the key when building the URL returned from Put/SignedURL,
+// e.g. "http://localhost:5034/api/pdf/artifacts".
+func NewLocalBackend(dir, baseURL string) (*LocalBackend, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("storage: creating local storage dir: %w", err)
+	}
+	return &LocalBackend{dir: dir, baseURL: baseURL}, nil
+}
+
+func (b *LocalBackend) objectPath(key string) string {
+	return filepath.Join(b.dir, filepath.Base(key))
+}
+
+func (b *LocalBackend) metaPath(key string) string {
+	return b.objectPath(key) + ".meta.json"
+}
+
+func (b *LocalBackend) Put(_ context.Context, key string, r io.Reader, meta Metadata) (string, error) {
+	f, err := os.Create(b.objectPath(key))
+	if err != nil {
+		return "", fmt.Errorf("storage: creating local object %s: %w", key, err)
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return "", fmt.Errorf("storage: writing local object %s: %w", key, err)
+	}
+
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		return "", fmt.Errorf("storage: encoding metadata for %s: %w", key, err)
+	}
+	if err := os.WriteFile(b.metaPath(key), metaBytes, 0o644); err != nil {
+		return "", fmt.Errorf("storage: writing metadata for %s: %w", key, err)
+	}
+
+	return b.urlFor(key), nil
+}
+
+func (b *LocalBackend) Get(_ context.Context, key string) (io.ReadCloser, Metadata, error) {
+	var meta Metadata
+	metaBytes, err := os.ReadFile(b.metaPath(key))
+	if os.IsNotExist(err) {
+		return nil, Metadata{}, ErrNotFound
+	}
+	if err != nil {
+		return nil, Metadata{}, fmt.Errorf("storage: reading metadata for %s: %w", key, err)
+	}
+	if err := json.Unmarshal(metaBytes, &meta); err != nil {
+		return nil, Metadata{}, fmt.Errorf("storage: decoding metadata for %s: %w", key, err)
+	}
+
+	f, err := os.Open(b.objectPath(key))
+	if os.IsNotExist(err) {
+		return nil, Metadata{}, ErrNotFound
+	}
+	if err != nil {
+		return nil, Metadata{}, fmt.Errorf("storage: opening local object %s: %w", key, err)
+	}
+	return f, meta, nil
+}
+
+func (b *LocalBackend) Delete(_ context.Context, key string) error {
+	if err := os.Remove(b.objectPath(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("storage: deleting local object %s: %w", key, err)
+	}
+	if err := os.Remove(b.metaPath(key)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("storage: deleting metadata for %s: %w", key, err)
+	}
+	return nil
+}
+
+// SignedURL has no real expiry semantics on the local backend: the artifact
+// route has no other access control, so it just returns the same URL Put
+// would have returned. expiry is accepted for interface compatibility with
+// the cloud backends.
+func (b *LocalBackend) SignedURL(_ context.Context, key string, _ time.Duration) (string, error) {
+	if _, err := os.Stat(b.objectPath(key)); os.IsNotExist(err) {
+		return "", ErrNotFound
+	}
+	return b.urlFor(key), nil
+}
+
+func (b *LocalBackend) urlFor(key string) string {
+	return fmt.Sprintf("%s/%s", b.baseURL, key)
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"context"
+
+	"github.com/aliyun/aliyun-oss-go-sdk/oss"
+)
+
+// OSSBackend stores artifacts as objects in an Alibaba Cloud OSS bucket.
+type OSSBackend struct {
+	bucket *oss.Bucket
+	prefix string
+}
+
+// OSSConfig carries the env-derived settings for NewOSSBackend.
+type OSSConfig struct {
+	Endpoint        string
+	AccessKeyID     string
+	AccessKeySecret string
+	BucketName      string
+	Prefix          string
+}
+
+// NewOSSBackend builds an OSSBackend from explicit endpoint/credentials
+// settings.
+func NewOSSBackend(cfg OSSConfig) (*OSSBackend, error) {
+	client, err := oss.New(cfg.Endpoint, cfg.AccessKeyID, cfg.AccessKeySecret)
+	if err != nil {
+		return nil, fmt.Errorf("storage: creating OSS client: %w", err)
+	}
+
+	bucket, err := client.Bucket(cfg.BucketName)
+	if err != nil {
+		return nil, fmt.Errorf("storage: resolving OSS bucket %s: %w", cfg.BucketName, err)
+	}
+
+	return &OSSBackend{bucket: bucket, prefix: cfg.Prefix}, nil
+}
+
+func (b *OSSBackend) objectKey(key string) string {
+	if b.prefix == "" {
+		return key
+	}
+	return b.prefix + "/" + key
+}
+
+func (b *OSSBackend) Put(ctx context.Context, key string, r io.Reader, meta Metadata) (string, error) {
+	err := b.bucket.PutObject(b.objectKey(key), r,
+		oss.ContentType(meta.ContentType),
+		oss.ContentDisposition(fmt.Sprintf("attachment; filename=%s", meta.Filename)),
+	)
+	if err != nil {
+		return "", fmt.Errorf("storage: uploading %s to OSS: %w", key, err)
+	}
+	return b.SignedURL(ctx, key, 15*time.Minute)
+}
+
+func (b *OSSBackend) Get(_ context.Context, key string) (io.ReadCloser, Metadata, error) {
+	body, err := b.bucket.GetObject(b.objectKey(key))
+	if err != nil {
+		return nil, Metadata{}, fmt.Errorf("storage: fetching %s from OSS: %w", key, err)
+	}
+	return body, Metadata{}, nil
+}
+
+func (b *OSSBackend) Delete(_ context.Context, key string) error {
+	if err := b.bucket.DeleteObject(b.objectKey(key)); err != nil {
+		return fmt.Errorf("storage: deleting %s from OSS: %w", key, err)
+	}
+	return nil
+}
+
+func (b *OSSBackend) SignedURL(_ context.Context, key string, expiry time.Duration) (string, error) {
+	url, err := b.bucket.SignURL(b.objectKey(key), oss.HTTPGet, int64(expiry.Seconds()))
+	if err != nil {
+		return "", fmt.Errorf("storage: signing OSS URL for %s: %w", key, err)
+	}
+	return url, nil
+}
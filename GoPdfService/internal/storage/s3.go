@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Backend stores artifacts in an S3-compatible bucket (AWS S3, MinIO,
+// Cloudflare R2, …) reached through a caller-supplied endpoint.
+type S3Backend struct {
+	client *s3.Client
+	bucket string
+	prefix string
+}
+
+// S3Config carries the env-derived settings for NewS3Backend.
+type S3Config struct {
+	Bucket          string
+	Region          string
+	Endpoint        string // optional; set for MinIO/R2/other S3-compatible stores
+	AccessKeyID     string
+	SecretAccessKey string
+	Prefix          string
+	ForcePathStyle  bool
+}
+
+// NewS3Backend builds an S3Backend from explicit credentials/endpoint
+// settings, falling back to the default AWS credential chain when
+// AccessKeyID is empty.
+func NewS3Backend(ctx context.Context, cfg S3Config) (*S3Backend, error) {
+	optFns := []func(*config.LoadOptions) error{config.WithRegion(cfg.Region)}
+	if cfg.AccessKeyID != "" {
+		optFns = append(optFns, config.WithCredentialsProvider(
+			credentials.NewStaticCredentialsProvider(cfg.AccessKeyID, cfg.SecretAccessKey, ""),
+		))
+	}
+
+	awsCfg, err := config.LoadDefaultConfig(ctx, optFns...)
+	if err != nil {
+		return nil, fmt.Errorf("storage: loading AWS config: %w", err)
+	}
+
+	client := s3.NewFromConfig(awsCfg, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+		o.UsePathStyle = cfg.ForcePathStyle
+	})
+
+	return &S3Backend{client: client, bucket: cfg.Bucket, prefix: cfg.Prefix}, nil
+}
+
+func (b *S3Backend) objectKey(key string) string {
+	if b.prefix == "" {
+		return key
+	}
+	return b.prefix + "/" + key
+}
+
+func (b *S3Backend) Put(ctx context.Context, key string, r io.Reader, meta Metadata) (string, error) {
+	_, err := b.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:             aws.String(b.bucket),
+		Key:                aws.String(b.objectKey(key)),
+		Body:               r,
+		ContentType:        aws.String(meta.ContentType),
+		ContentDisposition: aws.String(fmt.Sprintf("attachment; filename=%s", meta.Filename)),
+	})
+	if err != nil {
+		return "", fmt.Errorf("storage: uploading %s to S3: %w", key, err)
+	}
+	return b.SignedURL(ctx, key, 15*time.Minute)
+}
+
+func (b *S3Backend) Get(ctx context.Context, key string) (io.ReadCloser, Metadata, error) {
+	out, err := b.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.objectKey(key)),
+	})
+	if err != nil {
+		return nil, Metadata{}, fmt.Errorf("storage: fetching %s from S3: %w", key, err)
+	}
+	meta := Metadata{}
+	if out.ContentType != nil {
+		meta.ContentType = *out.ContentType
+	}
+	return out.Body, meta, nil
+}
+
+func (b *S3Backend) Delete(ctx context.Context, key string) error {
+	_, err := b.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.objectKey(key)),
+	})
+	if err != nil {
+		return fmt.Errorf("storage: deleting %s from S3: %w", key, err)
+	}
+	return nil
+}
+
+func (b *S3Backend) SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error) {
+	presigner := s3.NewPresignClient(b.client)
+	req, err := presigner.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(b.objectKey(key)),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", fmt.Errorf("storage: presigning %s: %w", key, err)
+	}
+	return req.URL, nil
+}
@@ -0,0 +1,41 @@
+// Package storage persists uploaded and generated PDFs behind a pluggable
+// Backend, the same way Terraform registers remote-state backends by name:
+// callers select an implementation via STORAGE_DRIVER and talk only to the
+// Backend interface afterwards.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// ErrNotFound is returned by LocalBackend's Get/SignedURL when key does not
+// exist. The cloud backends return their SDK's own error, wrapped, instead.
+var ErrNotFound = errors.New("storage: object not found")
+
+// Metadata describes an object alongside its bytes.
+type Metadata struct {
+	ContentType string
+	Filename    string
+}
+
+// Backend stores and retrieves artifacts under an opaque string key.
+type Backend interface {
+	// Put uploads r under key and returns a URL clients can use to fetch it
+	// (a pre-signed URL for cloud backends, or a local artifact route).
+	Put(ctx context.Context, key string, r io.Reader, meta Metadata) (url string, err error)
+
+	// Get streams the object back along with its metadata. LocalBackend
+	// returns both fields as stored; the S3, Azure and GCS backends return
+	// only ContentType, and OSSBackend returns neither. The caller must close
+	// the returned reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, Metadata, error)
+
+	// Delete removes key. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+
+	// SignedURL returns a time-limited download URL for key.
+	SignedURL(ctx context.Context, key string, expiry time.Duration) (string, error)
+}
@@ -0,0 +1,18 @@
+package upload
+
+import (
+	"os"
+	"strconv"
+)
+
+func envInt64(name string, fallback int64) int64 {
+	raw := os.Getenv(name)
+	if raw == "" {
+		return fallback
+	}
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil || n <= 0 {
+		return fallback
+	}
+	return n
+}
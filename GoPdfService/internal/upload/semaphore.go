@@ -0,0 +1,29 @@
+package upload
+
+// Semaphore caps how many conversions run at once, so a burst of large
+// uploads can't all rasterize/merge/sign concurrently and exhaust memory.
+type Semaphore chan struct{}
+
+// MaxConcurrentFromEnv reads UPLOAD_MAX_CONCURRENT, defaulting to 4.
+func MaxConcurrentFromEnv() int {
+	return int(envInt64("UPLOAD_MAX_CONCURRENT", 4))
+}
+
+// NewSemaphore builds a Semaphore allowing up to n concurrent holders. n<=0
+// is treated as 1.
+func NewSemaphore(n int) Semaphore {
+	if n <= 0 {
+		n = 1
+	}
+	return make(Semaphore, n)
+}
+
+// Acquire blocks until a slot is free.
+func (s Semaphore) Acquire() {
+	s <- struct{}{}
+}
+
+// Release frees the slot taken by the matching Acquire.
+func (s Semaphore) Release() {
+	<-s
+}
@@ -0,0 +1,175 @@
+// Package upload streams multipart request bodies onto disk instead of
+// buffering them in memory, enforcing per-file and per-request size
+// ceilings so a single large scanned PDF can't exhaust the process.
+package upload
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+)
+
+// ErrPayloadTooLarge is returned when a part or the request as a whole
+// exceeds its configured limit. Handlers map it to HTTP 413.
+var ErrPayloadTooLarge = errors.New("upload: payload too large")
+
+// maxFieldBytes bounds a single non-file form field; form values are never
+// expected to carry more than a short string in this service.
+const maxFieldBytes = 1 << 20 // 1 MiB
+
+// Limits caps how much a single multipart request is allowed to cost.
+type Limits struct {
+	MaxFileBytes    int64 // per uploaded file
+	MaxRequestBytes int64 // across every part in the request
+	MaxPages        int   // per uploaded PDF, checked after it lands on disk
+}
+
+// LimitsFromEnv reads UPLOAD_MAX_FILE_BYTES, UPLOAD_MAX_REQUEST_BYTES and
+// UPLOAD_MAX_PAGES, falling back to conservative defaults when unset or
+// invalid.
+func LimitsFromEnv() Limits {
+	return Limits{
+		MaxFileBytes:    envInt64("UPLOAD_MAX_FILE_BYTES", 100<<20),
+		MaxRequestBytes: envInt64("UPLOAD_MAX_REQUEST_BYTES", 500<<20),
+		MaxPages:        int(envInt64("UPLOAD_MAX_PAGES", 2000)),
+	}
+}
+
+// File is one uploaded part that has been streamed to a temp file on disk.
+type File struct {
+	FieldName string
+	Filename  string
+	Path      string
+	Size      int64
+}
+
+// Open opens the landed temp file for reading.
+func (f File) Open() (*os.File, error) {
+	return os.Open(f.Path)
+}
+
+// Form is the result of streaming a multipart request to disk: uploaded
+// files grouped by form field name, and plain form values.
+type Form struct {
+	Files  map[string][]File
+	Values map[string]string
+}
+
+// File returns the first uploaded file under field, or ok=false if none was
+// sent.
+func (f *Form) File(field string) (File, bool) {
+	files := f.Files[field]
+	if len(files) == 0 {
+		return File{}, false
+	}
+	return files[0], true
+}
+
+// Close removes every temp file this Form landed on disk. Handlers should
+// defer it right after a successful Read.
+func (f *Form) Close() {
+	for _, files := range f.Files {
+		for _, file := range files {
+			os.Remove(file.Path)
+		}
+	}
+}
+
+// Read streams every part of r's multipart body onto disk (file parts) or
+// into memory (plain fields), enforcing limits as it goes. On error, any
+// files already landed are removed before returning.
+func Read(r *http.Request, limits Limits) (*Form, error) {
+	mr, err := r.MultipartReader()
+	if err != nil {
+		return nil, fmt.Errorf("upload: request is not multipart: %w", err)
+	}
+
+	form := &Form{Files: map[string][]File{}, Values: map[string]string{}}
+	var totalBytes int64
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			form.Close()
+			return nil, fmt.Errorf("upload: reading multipart body: %w", err)
+		}
+
+		if part.FileName() == "" {
+			value, n, err := readField(part, maxFieldBytes)
+			part.Close()
+			totalBytes += n
+			if err != nil || totalBytes > limits.MaxRequestBytes {
+				form.Close()
+				return nil, tooLargeOr(err)
+			}
+			form.Values[part.FormName()] = value
+			continue
+		}
+
+		file, n, err := landPart(part, limits.MaxFileBytes)
+		part.Close()
+		totalBytes += n
+		if err != nil || totalBytes > limits.MaxRequestBytes {
+			if file.Path != "" {
+				os.Remove(file.Path)
+			}
+			form.Close()
+			return nil, tooLargeOr(err)
+		}
+
+		field := part.FormName()
+		form.Files[field] = append(form.Files[field], file)
+	}
+
+	return form, nil
+}
+
+func tooLargeOr(err error) error {
+	if err != nil {
+		return err
+	}
+	return ErrPayloadTooLarge
+}
+
+func readField(r io.Reader, max int64) (string, int64, error) {
+	limited := io.LimitReader(r, max+1)
+	data, err := io.ReadAll(limited)
+	if err != nil {
+		return "", int64(len(data)), fmt.Errorf("upload: reading form field: %w", err)
+	}
+	if int64(len(data)) > max {
+		return "", int64(len(data)), ErrPayloadTooLarge
+	}
+	return string(data), int64(len(data)), nil
+}
+
+func landPart(part *multipart.Part, max int64) (File, int64, error) {
+	tmp, err := os.CreateTemp("", "upload-*")
+	if err != nil {
+		return File{}, 0, fmt.Errorf("upload: creating temp file: %w", err)
+	}
+	defer tmp.Close()
+
+	written, err := io.Copy(tmp, io.LimitReader(part, max+1))
+	if err != nil {
+		os.Remove(tmp.Name())
+		return File{}, written, fmt.Errorf("upload: writing temp file: %w", err)
+	}
+	if written > max {
+		os.Remove(tmp.Name())
+		return File{}, written, ErrPayloadTooLarge
+	}
+
+	return File{
+		FieldName: part.FormName(),
+		Filename:  part.FileName(),
+		Path:      tmp.Name(),
+		Size:      written,
+	}, written, nil
+}
@@ -2,11 +2,17 @@ package main
 
 import (
 	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -17,11 +23,32 @@ import (
 	"github.com/boombuler/barcode/code128"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
-	"github.com/google/uuid"
 	"github.com/jung-kurt/gofpdf"
+	"github.com/leszekszpunar/IronPDF-examples/GoPdfService/docs"
+	"github.com/leszekszpunar/IronPDF-examples/GoPdfService/internal/codes"
+	"github.com/leszekszpunar/IronPDF-examples/GoPdfService/internal/pdf"
+	"github.com/leszekszpunar/IronPDF-examples/GoPdfService/internal/signature"
+	"github.com/leszekszpunar/IronPDF-examples/GoPdfService/internal/storage"
+	"github.com/leszekszpunar/IronPDF-examples/GoPdfService/internal/upload"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
+// artifactStore is the backend selected by STORAGE_DRIVER; endpoints that
+// produce a file persist it here when called with ?store=1.
+var artifactStore storage.Backend
+
+// uploadLimits caps per-file and per-request size for every multipart
+// endpoint, configured via the UPLOAD_MAX_* environment variables. Its PDF
+// page count is checked only for PDFs the merge endpoints open through
+// openUploadedPDF; the other endpoints do not check it.
+var uploadLimits upload.Limits
+
+// conversionLimiter bounds how many landed uploads are converted at once.
+// readUploadForm takes a slot only after the files are on disk, so landing
+// uploads is not bounded by it.
+var conversionLimiter upload.Semaphore
+
 // @title Go PDF Service API
 // @version 1.0
 // @description Zaawansowany serwis PDF w Go z obsługą certyfikatów, podpisów i kodów kreskowych
@@ -35,14 +62,24 @@ type HealthResponse struct {
 	Version   string    `json:"version"`
 }
 
+// UploadLimitsResponse reports the ceilings enforced by the internal/upload
+// package, so clients can size requests before hitting a 413.
+type UploadLimitsResponse struct {
+	MaxFileBytes             int64 `json:"maxFileBytes"`
+	MaxRequestBytes          int64 `json:"maxRequestBytes"`
+	MaxPages                 int   `json:"maxPages"`
+	MaxConcurrentConversions int   `json:"maxConcurrentConversions"`
+}
+
 type SupportedFormatsResponse struct {
-	Service                  string   `json:"service"`
-	Description              string   `json:"description"`
-	SupportedImageFormats    []string `json:"supportedImageFormats"`
-	SupportedPdfFormats      []string `json:"supportedPdfFormats"`
-	SupportedDocumentFormats []string `json:"supportedDocumentFormats"`
-	SupportedOutputFormats   []string `json:"supportedOutputFormats"`
-	Features                 []string `json:"features"`
+	Service                  string               `json:"service"`
+	Description              string               `json:"description"`
+	SupportedImageFormats    []string             `json:"supportedImageFormats"`
+	SupportedPdfFormats      []string             `json:"supportedPdfFormats"`
+	SupportedDocumentFormats []string             `json:"supportedDocumentFormats"`
+	SupportedOutputFormats   []string             `json:"supportedOutputFormats"`
+	Features                 []string             `json:"features"`
+	Limits                   UploadLimitsResponse `json:"limits"`
 }
 
 type ErrorResponse struct {
@@ -53,6 +90,15 @@ func main() {
 	gin.SetMode(gin.ReleaseMode)
 	r := gin.Default()
 
+	store, err := storage.NewFromEnv(context.Background())
+	if err != nil {
+		log.Fatalf("Błąd podczas inicjalizacji backendu storage: %v", err)
+	}
+	artifactStore = store
+
+	uploadLimits = upload.LimitsFromEnv()
+	conversionLimiter = upload.NewSemaphore(upload.MaxConcurrentFromEnv())
+
 	// CORS configuration
 	config := cors.DefaultConfig()
 	config.AllowAllOrigins = true
@@ -88,6 +134,7 @@ func main() {
 			pdf.POST("/read-qr-codes", readQRCodes)
 			pdf.POST("/read-all-codes", readAllCodes)
 			pdf.GET("/supported-formats", getSupportedFormats)
+			pdf.GET("/artifacts/:key", getArtifact)
 		}
 	}
 
@@ -95,12 +142,37 @@ func main() {
 	if port == "" {
 		port = "5034"
 	}
+	docs.SwaggerInfo.Host = "localhost:" + port
+
+	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	log.Printf("Go PDF Service uruchomiony na porcie %s", port)
 	log.Printf("Health check: http://localhost:%s/health", port)
+	log.Printf("Dokumentacja API: http://localhost:%s/swagger/index.html", port)
 	log.Fatal(r.Run(":" + port))
 }
 
+// readUploadForm streams the request's multipart body to temp files on disk
+// under uploadLimits, writing a 413 response itself when a limit is hit
+// mid-stream (400 for any other malformed-request error). On success it
+// acquires a conversionLimiter slot for the duration of the caller's
+// processing; callers must defer the returned release func and, once done
+// reading the landed files, defer form.Close() to remove them.
+func readUploadForm(c *gin.Context) (form *upload.Form, release func(), ok bool) {
+	form, err := upload.Read(c.Request, uploadLimits)
+	if err != nil {
+		if errors.Is(err, upload.ErrPayloadTooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{Message: "Przekroczono dopuszczalny rozmiar przesyłanych danych"})
+		} else {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Message: fmt.Sprintf("Błąd podczas przetwarzania formularza: %v", err)})
+		}
+		return nil, nil, false
+	}
+
+	conversionLimiter.Acquire()
+	return form, conversionLimiter.Release, true
+}
+
 // @Summary Łączy kilka plików PDF w jeden dokument
 // @Description Łączy przekazane pliki PDF w jeden dokument
 // @Tags PDF Operations
@@ -108,35 +180,47 @@ func main() {
 // @Produce application/pdf
 // @Param files formData file true "Pliki PDF do połączenia"
 // @Param outputFormat query string false "Format wyjściowy (A4, A3, A5, LETTER)"
+// @Param xrefStream query bool false "Zapisz tablicę xref jako strumień (PDF 1.5) zamiast klasycznej tabeli"
+// @Param store query bool false "Zapisz wynik w skonfigurowanym backendzie storage i zwróć odnośnik zamiast bajtów"
 // @Success 200 {file} file "Połączony plik PDF"
 // @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/pdf/merge-pdfs [post]
 func mergePDFs(c *gin.Context) {
-	form, err := c.MultipartForm()
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Błąd podczas przetwarzania formularza"})
+	form, release, ok := readUploadForm(c)
+	if !ok {
 		return
 	}
+	defer form.Close()
+	defer release()
 
-	files := form.File["files"]
+	files := form.Files["files"]
 	if len(files) == 0 {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Nie przekazano żadnych plików"})
 		return
 	}
 
-	outputFormat := c.Query("outputFormat")
-	if outputFormat == "" {
-		outputFormat = "A4"
+	docs, err := openUploadedPDFs(files)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+		return
 	}
 
-	// Implementacja łączenia PDF (uproszczona)
-	// W rzeczywistej implementacji użyj biblioteki do łączenia PDF
+	merged, err := pdf.Merge(docs, mergeOptionsFromQuery(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Message: fmt.Sprintf("Błąd podczas łączenia PDF: %v", err)})
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message": fmt.Sprintf("Połączono %d plików PDF", len(files)),
-		"format":  outputFormat,
-	})
+	filename := fmt.Sprintf("merged_%d.pdf", time.Now().Unix())
+	if maybeStoreArtifact(c, filename, "application/pdf", merged) {
+		return
+	}
+
+	c.Header("Content-Type", "application/pdf")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Data(http.StatusOK, "application/pdf", merged)
 }
 
 // @Summary Konwertuje obrazy do formatu PDF
@@ -146,18 +230,21 @@ func mergePDFs(c *gin.Context) {
 // @Produce application/pdf
 // @Param files formData file true "Pliki obrazów do konwersji"
 // @Param outputFormat query string false "Format wyjściowy (A4, A3, A5, LETTER)"
+// @Param store query bool false "Zapisz wynik w skonfigurowanym backendzie storage i zwróć odnośnik zamiast bajtów"
 // @Success 200 {file} file "Plik PDF zawierający obrazy"
 // @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/pdf/images-to-pdf [post]
 func imagesToPDF(c *gin.Context) {
-	form, err := c.MultipartForm()
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Błąd podczas przetwarzania formularza"})
+	form, release, ok := readUploadForm(c)
+	if !ok {
 		return
 	}
+	defer form.Close()
+	defer release()
 
-	files := form.File["files"]
+	files := form.Files["files"]
 	if len(files) == 0 {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Nie przekazano żadnych plików"})
 		return
@@ -169,24 +256,28 @@ func imagesToPDF(c *gin.Context) {
 	}
 
 	// Implementacja konwersji obrazów do PDF
-	pdf := gofpdf.New("P", "mm", outputFormat, "")
+	page := gofpdf.New("P", "mm", outputFormat, "")
 
 	for _, file := range files {
 		if isImageFile(file.Filename) {
-			pdf.AddPage()
-			pdf.Image(file.Filename, 0, 0, 210, 297, false, "", 0, "")
+			page.AddPage()
+			page.Image(file.Path, 0, 0, 210, 297, false, imageTypeFromFilename(file.Filename), 0, "")
 		}
 	}
 
 	var buf bytes.Buffer
-	err = pdf.Output(&buf)
-	if err != nil {
+	if err := page.Output(&buf); err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Message: "Błąd podczas generowania PDF"})
 		return
 	}
 
+	filename := fmt.Sprintf("images_to_pdf_%d.pdf", time.Now().Unix())
+	if maybeStoreArtifact(c, filename, "application/pdf", buf.Bytes()) {
+		return
+	}
+
 	c.Header("Content-Type", "application/pdf")
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=images_to_pdf_%d.pdf", time.Now().Unix()))
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
 	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
 }
 
@@ -197,18 +288,22 @@ func imagesToPDF(c *gin.Context) {
 // @Produce application/pdf
 // @Param files formData file true "Pliki PDF i obrazy do połączenia"
 // @Param outputFormat query string false "Format wyjściowy (A4, A3, A5, LETTER)"
+// @Param xrefStream query bool false "Zapisz tablicę xref jako strumień (PDF 1.5) zamiast klasycznej tabeli"
+// @Param store query bool false "Zapisz wynik w skonfigurowanym backendzie storage i zwróć odnośnik zamiast bajtów"
 // @Success 200 {file} file "Połączony plik PDF"
 // @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/pdf/merge-all [post]
 func mergeAll(c *gin.Context) {
-	form, err := c.MultipartForm()
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Błąd podczas przetwarzania formularza"})
+	form, release, ok := readUploadForm(c)
+	if !ok {
 		return
 	}
+	defer form.Close()
+	defer release()
 
-	files := form.File["files"]
+	files := form.Files["files"]
 	if len(files) == 0 {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Nie przekazano żadnych plików"})
 		return
@@ -219,29 +314,40 @@ func mergeAll(c *gin.Context) {
 		outputFormat = "A4"
 	}
 
-	// Implementacja łączenia wszystkich plików
-	pdf := gofpdf.New("P", "mm", outputFormat, "")
-
+	var docs []*pdf.Document
 	for _, file := range files {
-		pdf.AddPage()
-		if isPDFFile(file.Filename) {
-			// Dodaj stronę z PDF
-		} else if isImageFile(file.Filename) {
-			// Dodaj obraz
-			pdf.Image(file.Filename, 0, 0, 210, 297, false, "", 0, "")
+		switch {
+		case isPDFFile(file.Filename):
+			doc, err := openUploadedPDF(file)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+				return
+			}
+			docs = append(docs, doc)
+		case isImageFile(file.Filename):
+			doc, err := imageToPDFDocument(file, outputFormat)
+			if err != nil {
+				c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+				return
+			}
+			docs = append(docs, doc)
 		}
 	}
 
-	var buf bytes.Buffer
-	err = pdf.Output(&buf)
+	merged, err := pdf.Merge(docs, mergeOptionsFromQuery(c))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Message: "Błąd podczas generowania PDF"})
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Message: fmt.Sprintf("Błąd podczas łączenia plików: %v", err)})
+		return
+	}
+
+	filename := fmt.Sprintf("merged_all_%d.pdf", time.Now().Unix())
+	if maybeStoreArtifact(c, filename, "application/pdf", merged) {
 		return
 	}
 
 	c.Header("Content-Type", "application/pdf")
-	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=merged_all_%d.pdf", time.Now().Unix()))
-	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Data(http.StatusOK, "application/pdf", merged)
 }
 
 // @Summary Ekstrahuje tekst z pliku PDF
@@ -252,11 +358,19 @@ func mergeAll(c *gin.Context) {
 // @Param file formData file true "Plik PDF"
 // @Success 200 {file} file "Plik tekstowy z wyekstrahowaną treścią"
 // @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/pdf/extract-text [post]
 func extractText(c *gin.Context) {
-	file, err := c.FormFile("file")
-	if err != nil {
+	form, release, ok := readUploadForm(c)
+	if !ok {
+		return
+	}
+	defer form.Close()
+	defer release()
+
+	file, ok := form.File("file")
+	if !ok {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Nie przekazano pliku"})
 		return
 	}
@@ -283,11 +397,19 @@ func extractText(c *gin.Context) {
 // @Param file formData file true "Plik DOC/DOCX"
 // @Success 200 {file} file "Plik PDF"
 // @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/pdf/doc-to-pdf [post]
 func docToPDF(c *gin.Context) {
-	file, err := c.FormFile("file")
-	if err != nil {
+	form, release, ok := readUploadForm(c)
+	if !ok {
+		return
+	}
+	defer form.Close()
+	defer release()
+
+	file, ok := form.File("file")
+	if !ok {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Nie przekazano pliku"})
 		return
 	}
@@ -315,11 +437,19 @@ func docToPDF(c *gin.Context) {
 // @Param text formData string false "Tekst do zakodowania w QR"
 // @Success 200 {file} file "Plik PDF z kodem QR"
 // @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/pdf/add-qr-code [post]
 func addQRCode(c *gin.Context) {
-	file, err := c.FormFile("file")
-	if err != nil {
+	form, release, ok := readUploadForm(c)
+	if !ok {
+		return
+	}
+	defer form.Close()
+	defer release()
+
+	file, ok := form.File("file")
+	if !ok {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Nie przekazano pliku PDF"})
 		return
 	}
@@ -329,7 +459,7 @@ func addQRCode(c *gin.Context) {
 		return
 	}
 
-	text := c.PostForm("text")
+	text := form.Values["text"]
 	if text == "" {
 		text = "https://example.com"
 	}
@@ -350,11 +480,19 @@ func addQRCode(c *gin.Context) {
 // @Param text formData string false "Tekst do zakodowania w kodzie kreskowym"
 // @Success 200 {file} file "Plik PDF z kodem kreskowym"
 // @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/pdf/add-barcode [post]
 func addBarcode(c *gin.Context) {
-	file, err := c.FormFile("file")
-	if err != nil {
+	form, release, ok := readUploadForm(c)
+	if !ok {
+		return
+	}
+	defer form.Close()
+	defer release()
+
+	file, ok := form.File("file")
+	if !ok {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Nie przekazano pliku PDF"})
 		return
 	}
@@ -364,13 +502,13 @@ func addBarcode(c *gin.Context) {
 		return
 	}
 
-	text := c.PostForm("text")
+	text := form.Values["text"]
 	if text == "" {
 		text = "123456789"
 	}
 
 	// Implementacja dodawania kodu kreskowego
-	_, err = code128.Encode(text)
+	_, err := code128.Encode(text)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Message: "Błąd podczas generowania kodu kreskowego"})
 		return
@@ -388,13 +526,28 @@ func addBarcode(c *gin.Context) {
 // @Accept multipart/form-data
 // @Produce application/pdf
 // @Param file formData file true "Plik PDF"
+// @Param p12 formData file false "Plik PKCS#12 (.p12/.pfx) z kluczem i certyfikatem (opcjonalnie, jeśli skonfigurowano SIGNING_KEYSTORE_PATH)"
+// @Param password formData string false "Hasło do magazynu PKCS#12"
+// @Param reason formData string false "Powód podpisania"
+// @Param location formData string false "Miejsce podpisania"
+// @Param page formData int false "Numer strony, na której umieścić pole podpisu (domyślnie 1)"
+// @Param cades formData bool false "Użyj /ETSI.CAdES.detached zamiast adbe.pkcs7.detached (PAdES-B-B)"
+// @Param store query bool false "Zapisz wynik w skonfigurowanym backendzie storage i zwróć odnośnik zamiast bajtów"
 // @Success 200 {file} file "Podpisany plik PDF"
 // @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/pdf/sign [post]
 func signPDF(c *gin.Context) {
-	file, err := c.FormFile("file")
-	if err != nil {
+	form, release, ok := readUploadForm(c)
+	if !ok {
+		return
+	}
+	defer form.Close()
+	defer release()
+
+	file, ok := form.File("file")
+	if !ok {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Nie przekazano pliku PDF"})
 		return
 	}
@@ -404,19 +557,42 @@ func signPDF(c *gin.Context) {
 		return
 	}
 
-	// Implementacja podpisywania cyfrowego
-	// Generuj klucz i certyfikat
-	_ = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"filename":  file.Filename,
-		"timestamp": time.Now().Unix(),
-		"id":        uuid.New().String(),
-	})
+	pdfData, err := os.ReadFile(file.Path)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Message: fmt.Sprintf("nie można odczytać pliku %s", file.Filename)})
+		return
+	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message":   "PDF podpisany cyfrowo",
-		"filename":  file.Filename,
-		"signature": "podpis_cyfrowy",
-	})
+	ks, err := loadKeyStoreForRequest(form)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	opts := signature.SignOptions{
+		Reason:     form.Values["reason"],
+		Location:   form.Values["location"],
+		PageNumber: pageNumberFromForm(form),
+		SubFilter:  signature.SubFilterAdbePKCS7Detached,
+	}
+	if form.Values["cades"] == "true" || form.Values["cades"] == "1" {
+		opts.SubFilter = signature.SubFilterETSICAdESDetached
+	}
+
+	signed, err := signature.Sign(pdfData, ks, opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Message: fmt.Sprintf("Błąd podczas podpisywania PDF: %v", err)})
+		return
+	}
+
+	filename := fmt.Sprintf("signed_%d.pdf", time.Now().Unix())
+	if maybeStoreArtifact(c, filename, "application/pdf", signed) {
+		return
+	}
+
+	c.Header("Content-Type", "application/pdf")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	c.Data(http.StatusOK, "application/pdf", signed)
 }
 
 // @Summary Weryfikuje podpis cyfrowy w PDF
@@ -427,11 +603,19 @@ func signPDF(c *gin.Context) {
 // @Param file formData file true "Plik PDF"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/pdf/verify-signature [post]
 func verifySignature(c *gin.Context) {
-	file, err := c.FormFile("file")
-	if err != nil {
+	form, release, ok := readUploadForm(c)
+	if !ok {
+		return
+	}
+	defer form.Close()
+	defer release()
+
+	file, ok := form.File("file")
+	if !ok {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Nie przekazano pliku PDF"})
 		return
 	}
@@ -441,12 +625,38 @@ func verifySignature(c *gin.Context) {
 		return
 	}
 
-	// Implementacja weryfikacji podpisu
+	pdfData, err := os.ReadFile(file.Path)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Message: fmt.Sprintf("nie można odczytać pliku %s", file.Filename)})
+		return
+	}
+
+	trustRoots, err := loadTrustStore()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	result, err := signature.Verify(pdfData, trustRoots)
+	if err != nil {
+		c.JSON(http.StatusOK, gin.H{
+			"verified":  false,
+			"message":   fmt.Sprintf("Nie udało się zweryfikować podpisu: %v", err),
+			"timestamp": time.Now(),
+		})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"verified":  false,
-		"message":   "Funkcja weryfikacji podpisu wymaga implementacji",
-		"timestamp": time.Now(),
+		"verified":     result.IntegrityIntact,
+		"chainTrusted": result.ChainTrusted,
+		"signerDN":     result.SignerDN,
+		"signingTime":  result.SigningTime,
+		"reason":       result.Reason,
+		"location":     result.Location,
+		"subFilter":    result.SubFilter,
+		"errors":       result.Errors,
+		"timestamp":    time.Now(),
 	})
 }
 
@@ -474,11 +684,167 @@ func getSupportedFormats(c *gin.Context) {
 			"Podpisy cyfrowe",
 			"Weryfikacja podpisów",
 		},
+		Limits: UploadLimitsResponse{
+			MaxFileBytes:             uploadLimits.MaxFileBytes,
+			MaxRequestBytes:          uploadLimits.MaxRequestBytes,
+			MaxPages:                 uploadLimits.MaxPages,
+			MaxConcurrentConversions: cap(conversionLimiter),
+		},
 	}
 
 	c.JSON(http.StatusOK, response)
 }
 
+// openUploadedPDF parses a landed upload as a PDF document, following its
+// xref chain, and rejects it if it exceeds uploadLimits.MaxPages.
+func openUploadedPDF(f upload.File) (*pdf.Document, error) {
+	raw, err := os.ReadFile(f.Path)
+	if err != nil {
+		return nil, fmt.Errorf("nie można odczytać pliku %s", f.Filename)
+	}
+
+	doc, err := pdf.Open(raw)
+	if err != nil {
+		return nil, fmt.Errorf("plik %s nie jest poprawnym dokumentem PDF: %v", f.Filename, err)
+	}
+
+	if uploadLimits.MaxPages > 0 {
+		if n, err := pdf.CountPages(doc); err == nil && n > uploadLimits.MaxPages {
+			return nil, fmt.Errorf("plik %s ma %d stron, co przekracza limit %d", f.Filename, n, uploadLimits.MaxPages)
+		}
+	}
+
+	return doc, nil
+}
+
+func openUploadedPDFs(files []upload.File) ([]*pdf.Document, error) {
+	docs := make([]*pdf.Document, 0, len(files))
+	for _, f := range files {
+		if !isPDFFile(f.Filename) {
+			return nil, fmt.Errorf("plik %s nie jest plikiem PDF", f.Filename)
+		}
+		doc, err := openUploadedPDF(f)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// imageToPDFDocument wraps a single uploaded image in a one-page PDF so it
+// can be merged through the same xref-based pipeline as native PDF inputs.
+func imageToPDFDocument(f upload.File, outputFormat string) (*pdf.Document, error) {
+	page := gofpdf.New("P", "mm", outputFormat, "")
+	page.AddPage()
+	page.Image(f.Path, 0, 0, 210, 297, false, imageTypeFromFilename(f.Filename), 0, "")
+
+	var buf bytes.Buffer
+	if err := page.Output(&buf); err != nil {
+		return nil, fmt.Errorf("błąd podczas konwersji obrazu %s do PDF", f.Filename)
+	}
+
+	return pdf.Open(buf.Bytes())
+}
+
+// imageTypeFromFilename derives the gofpdf image type ("JPG", "PNG", ...)
+// from filename's extension, since uploaded files land on disk under a
+// temp name that carries no extension of its own.
+func imageTypeFromFilename(filename string) string {
+	ext := strings.TrimPrefix(strings.ToLower(filepath.Ext(filename)), ".")
+	if ext == "jpeg" {
+		ext = "jpg"
+	}
+	return strings.ToUpper(ext)
+}
+
+// mergeOptionsFromQuery reads the ?xrefStream=1 flag so callers can request
+// a PDF 1.5 cross-reference stream instead of the classic xref table.
+func mergeOptionsFromQuery(c *gin.Context) pdf.MergeOptions {
+	return pdf.MergeOptions{
+		WriteOptions: pdf.WriteOptions{
+			UseXRefStream: c.Query("xrefStream") == "1",
+		},
+	}
+}
+
+// loadKeyStoreForRequest resolves the PKCS#12 signing identity either from
+// an uploaded "p12" form file, or from SIGNING_KEYSTORE_PATH /
+// SIGNING_KEYSTORE_PASSWORD when the request didn't supply its own.
+func loadKeyStoreForRequest(form *upload.Form) (*signature.KeyStore, error) {
+	password := form.Values["password"]
+
+	if p12, ok := form.File("p12"); ok {
+		raw, err := os.ReadFile(p12.Path)
+		if err != nil {
+			return nil, fmt.Errorf("nie można odczytać pliku %s", p12.Filename)
+		}
+		return signature.LoadKeyStore(raw, password)
+	}
+
+	keystorePath := os.Getenv("SIGNING_KEYSTORE_PATH")
+	if keystorePath == "" {
+		return nil, fmt.Errorf("nie przekazano pliku p12 i nie skonfigurowano SIGNING_KEYSTORE_PATH")
+	}
+	raw, err := os.ReadFile(keystorePath)
+	if err != nil {
+		return nil, fmt.Errorf("nie można odczytać skonfigurowanego magazynu kluczy: %v", err)
+	}
+	if password == "" {
+		password = os.Getenv("SIGNING_KEYSTORE_PASSWORD")
+	}
+	return signature.LoadKeyStore(raw, password)
+}
+
+// loadTrustStore reads every PEM certificate under SIGNATURE_TRUST_STORE_DIR
+// (if set) to use as the trusted root set when verifying a signature's
+// certificate chain. An empty/unset directory means chain trust is skipped
+// and only the cryptographic signature itself is checked.
+func loadTrustStore() ([]*x509.Certificate, error) {
+	dir := os.Getenv("SIGNATURE_TRUST_STORE_DIR")
+	if dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("nie można odczytać katalogu zaufanych certyfikatów: %v", err)
+	}
+
+	var roots []*x509.Certificate
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if ext != ".pem" && ext != ".crt" {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		block, _ := pem.Decode(raw)
+		if block == nil {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		roots = append(roots, cert)
+	}
+	return roots, nil
+}
+
+func pageNumberFromForm(form *upload.Form) int {
+	page, err := strconv.Atoi(form.Values["page"])
+	if err != nil || page < 1 {
+		return 1
+	}
+	return page
+}
+
 // Helper functions
 func isPDFFile(filename string) bool {
 	return strings.ToLower(filepath.Ext(filename)) == ".pdf"
@@ -512,24 +878,18 @@ func isDocumentFile(filename string) bool {
 // @Accept multipart/form-data
 // @Produce application/json
 // @Param file formData file true "Plik obrazu lub PDF"
+// @Param symbologies query string false "Lista symbologii 1D do odczytu, rozdzielona przecinkami (domyślnie wszystkie)"
+// @Param maxPages query int false "Maksymalna liczba stron PDF do przetworzenia"
+// @Param dpi query int false "Rozdzielczość rasteryzacji stron PDF (domyślnie 150)"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/pdf/read-barcodes [post]
 func readBarcodes(c *gin.Context) {
-	_, err := c.FormFile("file")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Nie przekazano pliku"})
-		return
-	}
-
-	// Uproszczona implementacja - zwracamy informację o funkcji
-	c.JSON(http.StatusOK, gin.H{
-		"success":   true,
-		"barcodes":  []map[string]interface{}{},
-		"count":     0,
-		"message":   "Funkcja odczytywania kodów kreskowych wymaga implementacji",
-		"timestamp": time.Now().Format(time.RFC3339),
+	decodeAndRespond(c, "barcodes", []codes.Symbology{
+		codes.SymbologyCode128, codes.SymbologyEAN13, codes.SymbologyEAN8,
+		codes.SymbologyUPCA, codes.SymbologyCode39,
 	})
 }
 
@@ -539,25 +899,15 @@ func readBarcodes(c *gin.Context) {
 // @Accept multipart/form-data
 // @Produce application/json
 // @Param file formData file true "Plik obrazu lub PDF"
+// @Param maxPages query int false "Maksymalna liczba stron PDF do przetworzenia"
+// @Param dpi query int false "Rozdzielczość rasteryzacji stron PDF (domyślnie 150)"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/pdf/read-qr-codes [post]
 func readQRCodes(c *gin.Context) {
-	_, err := c.FormFile("file")
-	if err != nil {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Nie przekazano pliku"})
-		return
-	}
-
-	// Uproszczona implementacja - zwracamy informację o funkcji
-	c.JSON(http.StatusOK, gin.H{
-		"success":   true,
-		"qrCodes":   []map[string]interface{}{},
-		"count":     0,
-		"message":   "Funkcja odczytywania kodów QR wymaga implementacji",
-		"timestamp": time.Now().Format(time.RFC3339),
-	})
+	decodeAndRespond(c, "qrCodes", []codes.Symbology{codes.SymbologyQR})
 }
 
 // @Summary Odczytywanie wszystkich kodów (kreskowych i QR) z obrazu/PDF
@@ -566,24 +916,171 @@ func readQRCodes(c *gin.Context) {
 // @Accept multipart/form-data
 // @Produce application/json
 // @Param file formData file true "Plik obrazu lub PDF"
+// @Param symbologies query string false "Lista symbologii do odczytu, rozdzielona przecinkami (domyślnie wszystkie)"
+// @Param maxPages query int false "Maksymalna liczba stron PDF do przetworzenia"
+// @Param dpi query int false "Rozdzielczość rasteryzacji stron PDF (domyślnie 150)"
 // @Success 200 {object} map[string]interface{}
 // @Failure 400 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /api/pdf/read-all-codes [post]
 func readAllCodes(c *gin.Context) {
-	_, err := c.FormFile("file")
-	if err != nil {
+	decodeAndRespond(c, "all", codes.AllSymbologies())
+}
+
+// decodeAndRespond implements the shared body of the three code-reading
+// endpoints: they only differ in which symbologies are allowed by default
+// and under which JSON key the results are reported.
+func decodeAndRespond(c *gin.Context, resultKey string, defaultSymbologies []codes.Symbology) {
+	form, release, ok := readUploadForm(c)
+	if !ok {
+		return
+	}
+	defer form.Close()
+	defer release()
+
+	file, ok := form.File("file")
+	if !ok {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Message: "Nie przekazano pliku"})
 		return
 	}
 
-	// Uproszczona implementacja - zwracamy informację o funkcji
+	data, err := os.ReadFile(file.Path)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Message: fmt.Sprintf("nie można odczytać pliku %s", file.Filename)})
+		return
+	}
+
+	symbologies := defaultSymbologies
+	if raw := c.Query("symbologies"); raw != "" {
+		symbologies = intersectSymbologies(codes.ParseSymbologies(raw), defaultSymbologies)
+	}
+
+	opts := codes.DecodeOptions{
+		Symbologies:  symbologies,
+		MaxPages:     maxPagesFromQuery(c),
+		DPI:          dpiFromQuery(c),
+		PdftoppmPath: os.Getenv("PDFTOPPM_PATH"),
+	}
+
+	results, err := codes.Decode(data, isPDFFile(file.Filename), opts)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Message: fmt.Sprintf("Błąd podczas odczytu kodów: %v", err)})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"success":   true,
-		"barcodes":  []map[string]interface{}{},
-		"qrCodes":   []map[string]interface{}{},
-		"count":     0,
-		"message":   "Funkcja odczytywania wszystkich kodów wymaga implementacji",
+		resultKey:   results,
+		"count":     len(results),
 		"timestamp": time.Now().Format(time.RFC3339),
 	})
 }
+
+func intersectSymbologies(requested, allowed []codes.Symbology) []codes.Symbology {
+	allowedSet := make(map[codes.Symbology]bool, len(allowed))
+	for _, s := range allowed {
+		allowedSet[s] = true
+	}
+	var out []codes.Symbology
+	for _, s := range requested {
+		if allowedSet[s] {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func maxPagesFromQuery(c *gin.Context) int {
+	n, err := strconv.Atoi(c.Query("maxPages"))
+	if err != nil || n < 0 {
+		return 0
+	}
+	return n
+}
+
+func dpiFromQuery(c *gin.Context) float64 {
+	n, err := strconv.Atoi(c.Query("dpi"))
+	if err != nil || n <= 0 {
+		return 0
+	}
+	return float64(n)
+}
+
+// maybeStoreArtifact persists data in artifactStore and writes a JSON
+// response carrying a pre-signed download URL when the caller passed
+// ?store=1, instead of streaming the raw bytes back. It reports whether it
+// handled the response, so callers fall back to their usual c.Data when it
+// returns false.
+func maybeStoreArtifact(c *gin.Context, filename, contentType string, data []byte) bool {
+	if c.Query("store") != "1" {
+		return false
+	}
+
+	key := newArtifactKey(filename)
+	if _, err := artifactStore.Put(c.Request.Context(), key, bytes.NewReader(data), storage.Metadata{
+		ContentType: contentType,
+		Filename:    filename,
+	}); err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Message: fmt.Sprintf("Błąd podczas zapisu artefaktu: %v", err)})
+		return true
+	}
+
+	url, err := artifactStore.SignedURL(c.Request.Context(), key, 15*time.Minute)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Message: fmt.Sprintf("Błąd podczas generowania odnośnika do artefaktu: %v", err)})
+		return true
+	}
+
+	c.Header("X-Artifact-Key", key)
+	c.JSON(http.StatusOK, gin.H{
+		"key":         key,
+		"downloadUrl": url,
+		"contentType": contentType,
+		"filename":    filename,
+	})
+	return true
+}
+
+func newArtifactKey(filename string) string {
+	return fmt.Sprintf("%d%s", time.Now().UnixNano(), strings.ToLower(filepath.Ext(filename)))
+}
+
+// @Summary Pobiera zapisany artefakt
+// @Description Strumieniuje plik wcześniej zapisany przez endpoint wywołany z ?store=1
+// @Tags Artifacts
+// @Produce application/octet-stream
+// @Param key path string true "Klucz artefaktu (z nagłówka X-Artifact-Key lub downloadUrl)"
+// @Success 200 {file} file "Zapisany plik"
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /api/pdf/artifacts/{key} [get]
+func getArtifact(c *gin.Context) {
+	key := c.Param("key")
+
+	r, meta, err := artifactStore.Get(c.Request.Context(), key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			c.JSON(http.StatusNotFound, ErrorResponse{Message: "Nie znaleziono artefaktu"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, ErrorResponse{Message: fmt.Sprintf("Błąd podczas pobierania artefaktu: %v", err)})
+		return
+	}
+	defer r.Close()
+
+	contentType := meta.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	filename := meta.Filename
+	if filename == "" {
+		filename = key
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", filename))
+	if _, err := io.Copy(c.Writer, r); err != nil {
+		log.Printf("Błąd podczas strumieniowania artefaktu %s: %v", key, err)
+	}
+}